@@ -0,0 +1,102 @@
+// anticheat 패키지는 점수를 저장하기 전에 불가능한 결과를 걸러내는 부정행위 검사를 담당합니다.
+// 리플레이 재생보다 가벼운 검사로, 걸린 제출은 거절하고 사유와 함께 suspicious_scores에 보관합니다.
+package anticheat
+
+import (
+	"fmt"
+	"time"
+
+	"games/backend/tetris"
+)
+
+// 검사 사유 코드입니다. suspicious_scores.reasons에 저장됩니다.
+const (
+	ReasonNegative       = "negative_values"    // 음수 값
+	ReasonScore          = "score_inconsistent" // 줄 수와 레벨로 얻을 수 없는 점수
+	ReasonLevel          = "level_inconsistent" // 플레이 시간으로 도달할 수 없는 레벨
+	ReasonRate           = "submission_rate"    // 짧은 시간에 너무 많은 제출
+	ReasonReplayMismatch = "replay_mismatch"    // 리플레이 재생 결과와 다른 제출
+)
+
+// Flag 검사에 걸린 사유 하나입니다.
+type Flag struct {
+	Reason string
+	Detail string
+}
+
+// Submission 검사할 테트리스 점수 제출입니다. Elapsed는 게임 세션 시작부터 제출까지 서버에서 잰 시간입니다.
+type Submission struct {
+	Score           int
+	Lines           int
+	Level           int
+	DurationMs      int
+	PiecesPlaced    int
+	MaxCombo        int
+	GarbageSurvived int
+	Elapsed         time.Duration
+}
+
+// Check 함수는 tetris.js의 점수표와 레벨 규칙으로 제출 값이 가능한지 검사하고 걸린 사유를 반환합니다.
+func Check(s Submission) []Flag {
+	if s.Score < 0 || s.Lines < 0 || s.Level < 0 || s.DurationMs < 0 ||
+		s.PiecesPlaced < 0 || s.MaxCombo < 0 || s.GarbageSurvived < 0 {
+		// 음수 값이 있으면 나머지 검사는 의미가 없음
+		return []Flag{{Reason: ReasonNegative, Detail: "음수 값이 포함되어 있습니다"}}
+	}
+
+	var flags []Flag
+	if flag, ok := checkLevel(s); !ok {
+		flags = append(flags, flag)
+	}
+	if flag, ok := checkScore(s); !ok {
+		flags = append(flags, flag)
+	}
+	return flags
+}
+
+// checkLevel 함수는 레벨이 30초마다 하나씩 오르는 규칙과 맞는지 검사합니다.
+// 레벨은 플레이 시간으로 정확히 정해지며, 세션 경과 시간으로 도달할 수 있는 레벨보다 높을 수 없습니다.
+func checkLevel(s Submission) (Flag, bool) {
+	expected := s.DurationMs/tetris.LevelUpInterval + 1
+	if s.Level != expected {
+		return Flag{ReasonLevel, fmt.Sprintf("플레이 시간 %dms의 레벨은 %d이지만 %d로 제출되었습니다", s.DurationMs, expected, s.Level)}, false
+	}
+
+	reachable := int(s.Elapsed/(tetris.LevelUpInterval*time.Millisecond)) + 1
+	if s.Level > reachable {
+		return Flag{ReasonLevel, fmt.Sprintf("세션 경과 시간 %v로는 레벨 %d까지만 가능하지만 %d로 제출되었습니다",
+			s.Elapsed.Round(time.Second), reachable, s.Level)}, false
+	}
+	return Flag{}, true
+}
+
+// checkScore 함수는 점수가 지운 줄 수, 최종 레벨, 최대 콤보로 얻을 수 있는 범위인지 검사합니다.
+func checkScore(s Submission) (Flag, bool) {
+	if s.MaxCombo > s.Lines || (s.Lines > 0 && s.MaxCombo == 0) {
+		return Flag{ReasonScore, fmt.Sprintf("최대 콤보 %d는 줄 수 %d와 맞지 않습니다", s.MaxCombo, s.Lines)}, false
+	}
+
+	low, high := ScoreRange(s.Lines, s.Level, s.MaxCombo)
+	if s.Score%10 != 0 || s.Score < low || s.Score > high {
+		return Flag{ReasonScore, fmt.Sprintf("줄 %d, 레벨 %d, 최대 콤보 %d로 가능한 점수는 %d~%d이지만 %d로 제출되었습니다",
+			s.Lines, s.Level, s.MaxCombo, low, high, s.Score)}, false
+	}
+	return Flag{}, true
+}
+
+// ScoreRange 함수는 줄 수, 최종 레벨, 최대 콤보로 얻을 수 있는 점수 범위를 반환합니다.
+// 줄 점수는 레벨 1의 싱글(줄당 40점)이 가장 낮고 최종 레벨의 테트리스(줄당 300 × 레벨)가 가장 높습니다.
+// 콤보 보너스는 줄을 지울 때마다 최대 (최대 콤보 - 1) × 50 × 레벨이고, i번째로 지울 때 (i - 1) × 50 × 레벨을 넘지 않습니다.
+func ScoreRange(lines, level, maxCombo int) (int, int) {
+	if lines == 0 {
+		return 0, 0
+	}
+
+	perLine := tetris.LinePoints[0]
+	best := tetris.LinePoints[3] / 4
+	low := perLine * lines
+
+	comboBonus := min(lines*max(maxCombo-1, 0), lines*(lines-1)/2) * 50
+	high := (best*lines + comboBonus) * level
+	return low, high
+}
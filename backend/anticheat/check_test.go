@@ -0,0 +1,101 @@
+package anticheat
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestScoreRange(t *testing.T) {
+	cases := []struct {
+		lines, level, maxCombo int
+		low, high              int
+	}{
+		{0, 1, 0, 0, 0},
+		{0, 7, 0, 0, 0},
+		{1, 1, 1, 40, 300},           // 싱글 한 번: 최대는 테트리스 줄당 300점
+		{3, 1, 5, 120, 1050},         // 콤보 보너스는 지운 횟수로 제한 (0 + 50 + 100)
+		{4, 2, 2, 160, 2800},         // (1200 + 4 × 50) × 2
+		{10, 3, 3, 400, 12000},       // (3000 + 10 × 2 × 50) × 3
+		{947, 12, 6, 37880, 6250200}, // tetris.js 봇 게임 (시드 4)의 줄 수, 레벨, 콤보
+		{100, 1, 100, 4000, 277500},  // 콤보 보너스 상한 100 × 99 / 2 × 50
+	}
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("lines%d/level%d/combo%d", tc.lines, tc.level, tc.maxCombo), func(t *testing.T) {
+			low, high := ScoreRange(tc.lines, tc.level, tc.maxCombo)
+			if low != tc.low || high != tc.high {
+				t.Fatalf("ScoreRange = %d~%d, 기대값 %d~%d", low, high, tc.low, tc.high)
+			}
+		})
+	}
+}
+
+// TestCheckAcceptsTetrisJSGames 함수는 tetris.js로 진행한 게임 결과가 검사를 통과하는지 확인합니다.
+// 기대값은 tetris/testdata/gen_parity.js가 만든 parity.json을 함께 사용합니다.
+func TestCheckAcceptsTetrisJSGames(t *testing.T) {
+	data, err := os.ReadFile("../tetris/testdata/parity.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fixture struct {
+		Games []struct {
+			Seed   uint32 `json:"seed"`
+			Result struct {
+				Score           int `json:"score"`
+				Lines           int `json:"lines"`
+				Level           int `json:"level"`
+				DurationMs      int `json:"durationMs"`
+				PiecesPlaced    int `json:"piecesPlaced"`
+				MaxCombo        int `json:"maxCombo"`
+				GarbageSurvived int `json:"garbageSurvived"`
+			} `json:"result"`
+		} `json:"games"`
+	}
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, game := range fixture.Games {
+		t.Run(fmt.Sprint(game.Seed), func(t *testing.T) {
+			r := game.Result
+			s := Submission{
+				Score:           r.Score,
+				Lines:           r.Lines,
+				Level:           r.Level,
+				DurationMs:      r.DurationMs,
+				PiecesPlaced:    r.PiecesPlaced,
+				MaxCombo:        r.MaxCombo,
+				GarbageSurvived: r.GarbageSurvived,
+				Elapsed:         time.Duration(r.DurationMs)*time.Millisecond + 2*time.Second,
+			}
+			if flags := Check(s); len(flags) > 0 {
+				t.Fatalf("tetris.js 게임이 검사에 걸렸습니다: %+v", flags)
+			}
+		})
+	}
+}
+
+func TestCheckRejects(t *testing.T) {
+	cases := map[string]struct {
+		s      Submission
+		reason string
+	}{
+		"음수":         {Submission{Score: -10}, ReasonNegative},
+		"레벨과 시간 불일치": {Submission{Level: 3, DurationMs: 30000, Elapsed: time.Hour}, ReasonLevel},
+		"세션보다 긴 플레이": {Submission{Level: 3, DurationMs: 60000, Elapsed: 30 * time.Second}, ReasonLevel},
+		"줄 없이 점수":    {Submission{Score: 40, Level: 1, Elapsed: time.Minute}, ReasonScore},
+		"범위 넘는 점수":   {Submission{Score: 310, Lines: 1, MaxCombo: 1, Level: 1, Elapsed: time.Minute}, ReasonScore},
+		"10점 단위 아님":  {Submission{Score: 45, Lines: 1, MaxCombo: 1, Level: 1, Elapsed: time.Minute}, ReasonScore},
+		"줄 수보다 큰 콤보": {Submission{Score: 40, Lines: 1, MaxCombo: 2, Level: 1, Elapsed: time.Minute}, ReasonScore},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			flags := Check(tc.s)
+			if len(flags) != 1 || flags[0].Reason != tc.reason {
+				t.Fatalf("Check = %+v, 사유 %s 하나를 기대했습니다", flags, tc.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package anticheat
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"games/backend/db/models"
+)
+
+// rateLimit 기간별 최대 점수 제출 수입니다.
+type rateLimit struct {
+	window time.Duration
+	max    int
+}
+
+// rateLimits 한 사용자가 한 게임에 제출할 수 있는 빈도입니다. 정상 플레이로는 넘기 어려운 값으로 정합니다.
+var rateLimits = []rateLimit{
+	{window: time.Minute, max: 6},
+	{window: time.Hour, max: 120},
+}
+
+// Service 제출 빈도 검사와 의심 점수 저장소(suspicious_scores)에 접근하는 서비스입니다.
+type Service struct {
+	db *sql.DB
+}
+
+// NewService 함수는 부정행위 검사 서비스를 생성합니다.
+func NewService(db *sql.DB) *Service {
+	return &Service{db: db}
+}
+
+// CheckRate 함수는 최근 점수 제출(닫힌 게임 세션) 수가 제한을 넘었는지 검사합니다.
+// 검증에 실패한 제출도 세션을 닫으므로 함께 집계됩니다.
+func (s *Service) CheckRate(game string, userID int) (*Flag, error) {
+	now := time.Now()
+	for _, limit := range rateLimits {
+		var count int
+		err := s.db.QueryRow(
+			`SELECT COUNT(*) FROM game_sessions
+			WHERE game = $1 AND user_id = $2 AND finished_at > $3`,
+			game, userID, now.Add(-limit.window),
+		).Scan(&count)
+		if err != nil {
+			return nil, fmt.Errorf("제출 빈도 조회 실패: %v", err)
+		}
+		if count > limit.max {
+			return &Flag{ReasonRate, fmt.Sprintf("최근 %v 동안 %d번 제출했습니다 (최대 %d번)", limit.window, count, limit.max)}, nil
+		}
+	}
+	return nil, nil
+}
+
+// Record 함수는 검사에 걸린 제출을 사유와 함께 저장합니다.
+func (s *Service) Record(game string, userID int, sessionID string, sub Submission, flags []Flag) error {
+	reasons := make([]string, 0, len(flags))
+	details := make(map[string]string, len(flags))
+	for _, flag := range flags {
+		reasons = append(reasons, flag.Reason)
+		details[flag.Reason] = flag.Detail
+	}
+	detailsJSON, err := json.Marshal(details)
+	if err != nil {
+		return fmt.Errorf("의심 사유 변환 실패: %v", err)
+	}
+
+	_, err = s.db.Exec(
+		`INSERT INTO suspicious_scores
+			(game, user_id, session_id, score, lines, level, duration_ms, max_combo, reasons, details, created_at)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7, $8, $9, $10, $11)`,
+		game, userID, sessionID, sub.Score, sub.Lines, sub.Level, sub.DurationMs, sub.MaxCombo,
+		pq.StringArray(reasons), string(detailsJSON), time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("의심 점수 저장 실패: %v", err)
+	}
+	return nil
+}
+
+// List 함수는 의심 점수 기록을 최신순으로 반환합니다. game이 비어 있으면 모든 게임을 조회합니다.
+func (s *Service) List(game string, limit, offset int) ([]models.SuspiciousScore, error) {
+	rows, err := s.db.Query(
+		`SELECT ss.id, ss.game, ss.user_id, u.username, u.nickname, COALESCE(ss.session_id, ''),
+			ss.score, ss.lines, ss.level, ss.duration_ms, ss.max_combo, ss.reasons, ss.details, ss.created_at
+		FROM suspicious_scores ss
+		JOIN users u ON u.id = ss.user_id
+		WHERE $1 = '' OR ss.game = $1
+		ORDER BY ss.created_at DESC, ss.id DESC
+		LIMIT $2 OFFSET $3`,
+		game, limit, offset,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("의심 점수 조회 실패: %v", err)
+	}
+	defer rows.Close()
+
+	records := []models.SuspiciousScore{}
+	for rows.Next() {
+		var record models.SuspiciousScore
+		var reasons pq.StringArray
+		var details []byte
+		if err := rows.Scan(&record.ID, &record.Game, &record.UserID, &record.Username, &record.Nickname, &record.SessionID,
+			&record.Score, &record.Lines, &record.Level, &record.DurationMs, &record.MaxCombo, &reasons, &details, &record.CreatedAt); err != nil {
+			return nil, fmt.Errorf("의심 점수 데이터 처리 실패: %v", err)
+		}
+		record.Reasons = reasons
+		if err := json.Unmarshal(details, &record.Details); err != nil {
+			return nil, fmt.Errorf("의심 사유 변환 실패: %v", err)
+		}
+		records = append(records, record)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("의심 점수 조회 실패: %v", err)
+	}
+	return records, nil
+}
@@ -0,0 +1,25 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ListSuspiciousScoresHandler 관리자가 부정행위 검사에 걸린 점수 제출을 최신순으로 조회합니다.
+// game 파라미터로 게임을 지정할 수 있으며, limit/offset으로 페이지를 나눕니다.
+func ListSuspiciousScoresHandler(c *gin.Context) {
+	limit, offset := paginationParams(c, 50)
+
+	records, err := anticheatService.List(c.Query("game"), limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "의심 점수 조회 실패"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"suspicious": records,
+		"limit":      limit,
+		"offset":     offset,
+	})
+}
@@ -2,6 +2,10 @@
 package api
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -9,7 +13,7 @@ import (
 	"github.com/golang-jwt/jwt/v4"
 	"golang.org/x/crypto/bcrypt"
 
-	"games/backend/config"
+	"games/backend/authsession"
 	"games/backend/db"
 	"games/backend/db/models"
 )
@@ -83,7 +87,7 @@ func SignupHandler(c *gin.Context) {
 	c.JSON(http.StatusCreated, user)
 }
 
-// LoginHandler 함수는 로그인을 처리합니다.
+// LoginHandler 함수는 로그인을 처리하고 액세스 토큰과 로그인 세션의 리프레시 토큰을 발급합니다.
 func LoginHandler(c *gin.Context) {
 	var req struct {
 		Username string `json:"username"`
@@ -115,19 +119,167 @@ func LoginHandler(c *gin.Context) {
 		return
 	}
 
-	// JWT 토큰 생성 (유효기간: 1시간)
-	expirationTime := time.Now().Add(1 * time.Hour)
+	// 기기별 로그인 세션과 리프레시 토큰 발급
+	refresh, err := authSessionService.Create(user.ID, requestClient(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "로그인 세션 생성에 실패했습니다."})
+		return
+	}
+
+	respondWithTokens(c, user, refresh)
+}
+
+// RefreshTokenHandler 함수는 리프레시 토큰으로 새 액세스 토큰과 새 리프레시 토큰을 발급합니다.
+// 사용한 리프레시 토큰은 다시 쓸 수 없으며, 다시 쓰이면 그 로그인 세션 전체를 폐기합니다.
+func RefreshTokenHandler(c *gin.Context) {
+	var req struct {
+		RefreshToken string `json:"refresh_token" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "잘못된 요청입니다."})
+		return
+	}
+
+	refresh, err := authSessionService.Rotate(req.RefreshToken, requestClient(c))
+	switch {
+	case errors.Is(err, authsession.ErrInvalidToken), errors.Is(err, authsession.ErrExpired), errors.Is(err, authsession.ErrReused):
+		c.JSON(http.StatusUnauthorized, gin.H{"message": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "토큰 갱신에 실패했습니다."})
+		return
+	}
+
+	// 닉네임이 바뀌었을 수 있으므로 사용자 정보를 다시 조회
+	var user models.User
+	err = db.DB.QueryRow("SELECT id, username, nickname FROM users WHERE id=$1", refresh.UserID).
+		Scan(&user.ID, &user.Username, &user.Nickname)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "사용자 조회에 실패했습니다."})
+		return
+	}
+
+	respondWithTokens(c, user, refresh)
+}
+
+// ListAuthSessionsHandler 함수는 현재 사용자의 로그인된 기기(세션) 목록을 반환합니다.
+func ListAuthSessionsHandler(c *gin.Context) {
+	userID := c.MustGet("userID").(int)
+	currentID := c.GetString("sessionID")
+
+	sessions, err := authSessionService.List(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "로그인 세션 조회에 실패했습니다."})
+		return
+	}
+	for i := range sessions {
+		sessions[i].Current = sessions[i].ID == currentID
+	}
+
+	c.JSON(http.StatusOK, gin.H{"sessions": sessions})
+}
+
+// RevokeAuthSessionHandler 함수는 현재 사용자의 로그인 세션 하나를 해제합니다.
+// 해제한 세션의 리프레시 토큰은 바로 쓸 수 없게 되고, 이미 발급된 액세스 토큰은 만료될 때까지 유효합니다.
+func RevokeAuthSessionHandler(c *gin.Context) {
+	userID := c.MustGet("userID").(int)
+	sessionID := c.Param("id")
+
+	err := authSessionService.Revoke(userID, sessionID)
+	if errors.Is(err, authsession.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "로그인 세션 해제에 실패했습니다."})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "로그인 세션을 해제했습니다", "id": sessionID})
+}
+
+// LogoutHandler 함수는 요청에 사용한 액세스 토큰을 폐기하고 그 로그인 세션을 해제합니다.
+// 폐기된 토큰은 만료 전이라도 AuthMiddleware에서 거절되며, 세션의 리프레시 토큰도 더 이상 쓸 수 없습니다.
+// 본문에 refresh_token이 있으면 그 토큰의 세션도 해제하므로, 액세스 토큰이 만료된 뒤에는 리프레시 토큰만으로 로그아웃할 수 있습니다.
+func LogoutHandler(c *gin.Context) {
+	var req struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "잘못된 요청입니다."})
+			return
+		}
+	}
+
+	userID, authenticated := c.Get("userID")
+	if !authenticated && req.RefreshToken == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "토큰이 제공되지 않았습니다."})
+		return
+	}
+
+	if authenticated {
+		tokenID := c.GetString("tokenID")
+		sessionID := c.GetString("sessionID")
+
+		// jti가 없는 이전 토큰은 개별 폐기할 수 없으므로 만료될 때까지 유효
+		if tokenID != "" {
+			if err := tokenRevocations.Revoke(tokenID, userID.(int), c.GetTime("tokenExpiresAt")); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"message": "로그아웃에 실패했습니다."})
+				return
+			}
+		}
+
+		if sessionID != "" {
+			if err := authSessionService.Revoke(userID.(int), sessionID); err != nil && !errors.Is(err, authsession.ErrNotFound) {
+				c.JSON(http.StatusInternalServerError, gin.H{"message": "로그인 세션 해제에 실패했습니다."})
+				return
+			}
+		}
+	}
+
+	// 이미 해제된 세션의 토큰이면 로그아웃된 것으로 처리
+	if req.RefreshToken != "" {
+		if err := authSessionService.RevokeToken(req.RefreshToken); err != nil && !errors.Is(err, authsession.ErrInvalidToken) {
+			c.JSON(http.StatusInternalServerError, gin.H{"message": "로그인 세션 해제에 실패했습니다."})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "로그아웃되었습니다."})
+}
+
+// hasAuthorization 함수는 요청에 Authorization 헤더가 있는지 반환합니다. (로그아웃 라우트에서 인증 적용 여부 결정에 사용)
+func hasAuthorization(c *gin.Context) bool {
+	return c.GetHeader("Authorization") != ""
+}
+
+// respondWithTokens 함수는 로그인 세션의 액세스 토큰을 발급해 리프레시 토큰과 함께 응답합니다.
+// 액세스 토큰은 설정한 유효 기간(기본 15분)이 지나면 만료되며, POST /auth/refresh로 다시 발급받습니다.
+func respondWithTokens(c *gin.Context, user models.User, refresh *authsession.Token) {
+	// 토큰마다 고유 ID(jti)를 부여해 로그아웃 시 이 토큰만 폐기할 수 있게 합니다.
+	var jti [16]byte
+	if _, err := rand.Read(jti[:]); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "토큰 생성에 실패했습니다."})
+		return
+	}
+
+	now := time.Now()
+	accessTokenTTL := appConfig.Auth.AccessTokenTTL
 	claims := &models.Claims{
-		ID:       user.ID,
-		Username: user.Username,
-		Nickname: user.Nickname,
+		ID:        user.ID,
+		Username:  user.Username,
+		Nickname:  user.Nickname,
+		SessionID: refresh.SessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
+			ID:        hex.EncodeToString(jti[:]),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(accessTokenTTL)),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString(config.JWTSecret)
+	// 현재 사용 중인 서명 키로 서명합니다. (키 디렉토리를 쓰면 헤더에 kid 포함)
+	tokenString, err := jwtKeys.Sign(claims)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"message": "토큰 생성에 실패했습니다."})
 		return
@@ -135,8 +287,27 @@ func LoginHandler(c *gin.Context) {
 
 	// 토큰과 닉네임 함께 반환
 	c.JSON(http.StatusOK, gin.H{
-		"token":    tokenString,
-		"nickname": user.Nickname,
-		"username": user.Username,
+		"token":                    tokenString,
+		"expires_in":               int(accessTokenTTL.Seconds()),
+		"refresh_token":            refresh.Value,
+		"refresh_token_expires_at": refresh.ExpiresAt,
+		"session_id":               refresh.SessionID,
+		"nickname":                 user.Nickname,
+		"username":                 user.Username,
 	})
 }
+
+// jwksMaxAge JWKS 응답을 캐시해도 되는 시간입니다. 새 키는 사용 시작 전에 이보다 일찍 추가해야 합니다.
+const jwksMaxAge = 5 * time.Minute
+
+// JWKSHandler 함수는 액세스 토큰 검증용 공개키 목록(JWKS)을 반환합니다.
+// HS256 공유 비밀키를 쓰는 경우에는 공개할 키가 없어 빈 목록을 반환합니다.
+func JWKSHandler(c *gin.Context) {
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(jwksMaxAge.Seconds())))
+	c.JSON(http.StatusOK, jwtKeys.JWKS())
+}
+
+// requestClient 함수는 로그인 세션 목록에 보여줄 요청 기기 정보를 반환합니다.
+func requestClient(c *gin.Context) authsession.Client {
+	return authsession.Client{UserAgent: c.Request.UserAgent(), IP: c.ClientIP()}
+}
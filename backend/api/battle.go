@@ -0,0 +1,74 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"games/backend/battle"
+	"games/backend/rating"
+)
+
+// TetrisBattleHandler WebSocket 연결을 받아 1대1 테트리스 대전 대기열에 넣습니다.
+// 레이팅이 비슷한 상대가 정해지면 같은 시드로 대전이 시작되고, 방 고루틴이 두 플레이어의 입력으로 게임을 진행해 가비지와 승자를 정합니다.
+func TetrisBattleHandler(c *gin.Context) {
+	userID := c.MustGet("userID").(int)
+	nickname := c.MustGet("nickname").(string)
+
+	// 매치메이킹에 쓸 레이팅은 업그레이드 전에 조회해 실패하면 일반 HTTP 오류로 응답
+	current, err := ratingService.Get(tetrisGame, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "레이팅 조회 실패"})
+		return
+	}
+
+	// 업그레이드에 실패하면 업그레이더가 오류 응답을 보냄
+	conn, err := wsUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+
+	battleHub.Join(battle.NewPlayer(conn, userID, nickname, current.Rating))
+}
+
+// recordBattle 함수는 끝난 대전 결과를 테트리스 레이팅에 반영합니다. 대전 방 고루틴에서 호출됩니다.
+func recordBattle(result battle.Result) (map[int]int, error) {
+	return ratingService.Record(tetrisGame, rating.Match{
+		RoomID:   result.RoomID,
+		Players:  result.Players,
+		WinnerID: result.WinnerID,
+		Reason:   result.Reason,
+		Duration: result.Duration,
+	})
+}
+
+// GetTetrisRatingLeaderboardHandler 함수는 테트리스 대전 레이팅 순위를 반환합니다.
+func GetTetrisRatingLeaderboardHandler(c *gin.Context) {
+	limit, offset := paginationParams(c, 10)
+
+	leaderboard, total, err := ratingService.Leaderboard(tetrisGame, limit, offset)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "레이팅 리더보드 조회 실패"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"leaderboard": leaderboard,
+		"total":       total,
+		"limit":       limit,
+		"offset":      offset,
+	})
+}
+
+// GetUserTetrisRatingHandler 함수는 현재 사용자의 테트리스 대전 레이팅과 전적을 반환합니다.
+func GetUserTetrisRatingHandler(c *gin.Context) {
+	userID := c.MustGet("userID").(int)
+
+	current, err := ratingService.Get(tetrisGame, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "레이팅 조회 실패"})
+		return
+	}
+
+	c.JSON(http.StatusOK, current)
+}
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"games/backend/db/models"
+	"games/backend/friend"
+	"games/backend/score"
+)
+
+// 리더보드 scope 파라미터 값입니다.
+const (
+	scopeGlobal  = "global"  // 전체 사용자 (기본값)
+	scopeFriends = "friends" // 나와 친구만 (로그인 필요)
+)
+
+// ListFriendsHandler 현재 사용자의 친구 목록과 받은/보낸 친구 요청을 조회합니다.
+func ListFriendsHandler(c *gin.Context) {
+	userID := c.MustGet("userID").(int)
+
+	list, err := friendService.List(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "친구 목록 조회 실패"})
+		return
+	}
+	c.JSON(http.StatusOK, list)
+}
+
+// SendFriendRequestHandler 친구 요청을 보냅니다. 상대가 이미 나에게 요청을 보냈다면 바로 친구가 됩니다.
+func SendFriendRequestHandler(c *gin.Context) {
+	userID := c.MustGet("userID").(int)
+
+	var req models.FriendRequest
+	if err := c.ShouldBindJSON(&req); err != nil || (req.UserID <= 0 && req.Nickname == "") {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "user_id 또는 nickname을 입력해주세요"})
+		return
+	}
+
+	targetID, err := friendService.FindUserID(req)
+	if err != nil {
+		friendError(c, err, "친구 요청 실패")
+		return
+	}
+
+	status, err := friendService.Request(userID, targetID)
+	if err != nil {
+		friendError(c, err, "친구 요청 실패")
+		return
+	}
+
+	message := "친구 요청을 보냈습니다"
+	if status == models.FriendshipAccepted {
+		message = "상대방의 친구 요청을 수락했습니다"
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message": message,
+		"user_id": targetID,
+		"status":  status,
+	})
+}
+
+// AcceptFriendRequestHandler 경로의 사용자가 보낸 친구 요청을 수락합니다.
+func AcceptFriendRequestHandler(c *gin.Context) {
+	userID := c.MustGet("userID").(int)
+	otherID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := friendService.Accept(userID, otherID); err != nil {
+		friendError(c, err, "친구 요청 수락 실패")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "친구 요청을 수락했습니다", "user_id": otherID})
+}
+
+// DeclineFriendRequestHandler 경로의 사용자가 보낸 친구 요청을 거절합니다.
+func DeclineFriendRequestHandler(c *gin.Context) {
+	userID := c.MustGet("userID").(int)
+	otherID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := friendService.Decline(userID, otherID); err != nil {
+		friendError(c, err, "친구 요청 거절 실패")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "친구 요청을 거절했습니다", "user_id": otherID})
+}
+
+// RemoveFriendHandler 친구를 삭제하거나 내가 보낸 친구 요청을 취소합니다.
+func RemoveFriendHandler(c *gin.Context) {
+	userID := c.MustGet("userID").(int)
+	otherID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	if err := friendService.Remove(userID, otherID); err != nil {
+		friendError(c, err, "친구 삭제 실패")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "친구를 삭제했습니다", "user_id": otherID})
+}
+
+// userIDParam 함수는 경로의 :userID를 읽습니다. 잘못된 값이면 응답을 작성하고 false를 반환합니다.
+func userIDParam(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("userID"))
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "잘못된 사용자 ID입니다"})
+		return 0, false
+	}
+	return id, true
+}
+
+// friendError 함수는 친구 서비스 오류를 HTTP 응답으로 변환합니다.
+func friendError(c *gin.Context, err error, message string) {
+	switch err {
+	case friend.ErrUserNotFound, friend.ErrRequestNotFound, friend.ErrNotFound:
+		c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+	case friend.ErrSelf:
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+	case friend.ErrAlreadyFriends, friend.ErrAlreadyRequested:
+		c.JSON(http.StatusConflict, gin.H{"message": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"message": message})
+	}
+}
+
+// friendsScope 함수는 친구 리더보드 요청인지 반환합니다. (라우트에서 인증 적용 여부 결정에 사용)
+func friendsScope(c *gin.Context) bool {
+	return c.Query("scope") == scopeFriends
+}
+
+// applyScope 함수는 scope 파라미터에 따라 리더보드 조회 대상을 제한합니다.
+// scope=friends면 현재 사용자와 친구만으로 순위를 매깁니다. 실패하면 응답을 작성하고 false를 반환합니다.
+func applyScope(c *gin.Context, query *score.LeaderboardQuery) (string, bool) {
+	scope := c.DefaultQuery("scope", scopeGlobal)
+	switch scope {
+	case scopeGlobal:
+		return scope, true
+	case scopeFriends:
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"message": "알 수 없는 scope입니다 (global, friends 중 하나)"})
+		return "", false
+	}
+
+	// 라우트의 middleware.AuthWhen(friendsScope)로 인증된 요청입니다.
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "인증이 필요합니다"})
+		return "", false
+	}
+
+	friendIDs, err := friendService.FriendIDs(userID.(int))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "친구 목록 조회 실패"})
+		return "", false
+	}
+	query.UserIDs = append(friendIDs, userID.(int))
+	return scope, true
+}
@@ -0,0 +1,234 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"games/backend/db"
+	"games/backend/game"
+	"games/backend/score"
+)
+
+// ListGamesHandler 카탈로그에 등록된 모든 게임 목록을 반환합니다. (개발 중인 게임은 enabled=false)
+func ListGamesHandler(c *gin.Context) {
+	games, err := game.Default.ListCurrent(db.DB)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "게임 목록 조회 실패"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"games": games})
+}
+
+// GetGameHandler 슬러그에 해당하는 게임 정보를 반환합니다.
+func GetGameHandler(c *gin.Context) {
+	g, ok := lookupGame(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, g)
+}
+
+// UpdateGameHandler 관리자가 게임 활성화 여부를 바꿉니다. 비활성화된 게임은 점수를 제출할 수 없습니다.
+func UpdateGameHandler(c *gin.Context) {
+	var req struct {
+		Enabled *bool `json:"enabled" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "유효하지 않은 요청입니다 (enabled 필수)"})
+		return
+	}
+
+	g, err := game.Default.SetEnabled(db.DB, c.Param("slug"), *req.Enabled)
+	if errors.Is(err, game.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"message": "게임을 찾을 수 없습니다"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "게임 설정 변경 실패"})
+		return
+	}
+	c.JSON(http.StatusOK, g)
+}
+
+// GetGameLeaderboardHandler 게임별 리더보드를 조회합니다. 게임의 점수 정렬 방향(높을수록/낮을수록 좋음)을 따릅니다.
+// period 파라미터(daily, weekly, monthly, all)로 집계 기간을, scope=friends로 친구 랭킹을 지정할 수 있습니다.
+func GetGameLeaderboardHandler(c *gin.Context) {
+	g, ok := game.Default.Get(c.Param("slug"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"message": "게임을 찾을 수 없습니다"})
+		return
+	}
+
+	query, period, err := leaderboardParams(c, 10)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	scope, ok := applyScope(c, &query)
+	if !ok {
+		return
+	}
+
+	leaderboard, total, err := scoreService.Leaderboard(g.Slug, query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "리더보드 조회 실패"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"game":        g.Slug,
+		"score_order": g.ScoreOrder,
+		"period":      periodResponse(period, query),
+		"ranking":     query.Mode,
+		"scope":       scope,
+		"leaderboard": leaderboard,
+		"pagination": gin.H{
+			"total":  total,
+			"limit":  query.Limit,
+			"offset": query.Offset,
+		},
+	})
+}
+
+// SubmitGameScoreHandler 게임별 점수를 제출합니다. 요청 본문은 게임의 score_fields 정의를 따라야 합니다.
+func SubmitGameScoreHandler(c *gin.Context) {
+	// 사용자 ID 가져오기 (JWT에서 추출)
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "인증되지 않은 사용자"})
+		return
+	}
+
+	g, ok := lookupGame(c)
+	if !ok {
+		return
+	}
+	if !g.Enabled {
+		c.JSON(http.StatusForbidden, gin.H{"message": "현재 이용할 수 없는 게임입니다"})
+		return
+	}
+	if g.ScoreEndpoint != "" {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "이 게임의 점수는 검증을 위해 " + g.ScoreEndpoint + " API로 제출해야 합니다"})
+		return
+	}
+
+	// 점수 데이터 바인딩 및 게임별 필드 검증
+	var body map[string]float64
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "유효하지 않은 점수 데이터"})
+		return
+	}
+	fields, err := g.ParseScore(body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	// 응답 순위 방식 (기록을 저장하기 전에 검증)
+	mode, err := rankModeParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	submission, err := scoreService.Submit(g.Slug, userID.(int), score.ResultFromFields(fields))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "점수 저장 실패"})
+		return
+	}
+
+	// 처리 후 최고 점수 기준 순위
+	rank, err := scoreService.Rank(g.Slug, submission.BestScore, mode)
+	if err != nil {
+		rank = 0 // 순위 조회 실패 시 0으로 설정
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "점수가 저장되었습니다",
+		"playId":    submission.PlayID,
+		"isNewBest": submission.IsNewBest,
+		"bestScore": submission.BestScore,
+		"rank":      rank,
+	})
+}
+
+// paginationParams 함수는 limit/offset 쿼리 파라미터를 읽습니다. limit은 최대 100입니다.
+func paginationParams(c *gin.Context, defaultLimit int) (int, int) {
+	limit := defaultLimit
+	offset := 0
+
+	if limitParam := c.Query("limit"); limitParam != "" {
+		if val, err := strconv.Atoi(limitParam); err == nil && val > 0 {
+			limit = val
+		}
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	if offsetParam := c.Query("offset"); offsetParam != "" {
+		if val, err := strconv.Atoi(offsetParam); err == nil && val >= 0 {
+			offset = val
+		}
+	}
+
+	return limit, offset
+}
+
+// leaderboardParams 함수는 페이지네이션, period, ranking 파라미터를 읽어 리더보드 조회 조건을 만듭니다.
+// 기간 경계는 서버 설정 시간대(기본 KST)의 자정 기준입니다.
+func leaderboardParams(c *gin.Context, defaultLimit int) (score.LeaderboardQuery, score.Period, error) {
+	limit, offset := paginationParams(c, defaultLimit)
+
+	period, err := score.ParsePeriod(c.Query("period"))
+	if err != nil {
+		return score.LeaderboardQuery{}, "", err
+	}
+
+	mode, err := rankModeParam(c)
+	if err != nil {
+		return score.LeaderboardQuery{}, "", err
+	}
+
+	from, to := period.Range(time.Now(), appConfig.Leaderboard.Location)
+	return score.LeaderboardQuery{Limit: limit, Offset: offset, From: from, To: to, Mode: mode}, period, nil
+}
+
+// rankModeParam 함수는 ranking 파라미터(competition, dense)를 읽습니다. 없으면 서버 설정의 기본 순위 방식을 사용합니다.
+// 리더보드와 개별 순위 응답이 같은 방식을 쓰도록 순위를 반환하는 모든 API에서 사용합니다.
+func rankModeParam(c *gin.Context) (score.RankMode, error) {
+	value := c.Query("ranking")
+	if value == "" {
+		value = appConfig.Leaderboard.Ranking
+	}
+	return score.ParseRankMode(value)
+}
+
+// periodResponse 함수는 응답에 포함할 집계 기간 정보를 만듭니다. 전체 기간이면 시작/끝이 null입니다.
+func periodResponse(period score.Period, query score.LeaderboardQuery) gin.H {
+	response := gin.H{"name": period, "start": nil, "end": nil}
+	if !query.From.IsZero() {
+		response["start"] = query.From
+		response["end"] = query.To
+	}
+	return response
+}
+
+// lookupGame 함수는 경로의 slug에 해당하는 게임을 DB의 현재 활성화 여부와 함께 조회합니다. 실패하면 응답을 작성하고 false를 반환합니다.
+func lookupGame(c *gin.Context) (game.Game, bool) {
+	g, err := game.Default.Lookup(db.DB, c.Param("slug"))
+	if errors.Is(err, game.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"message": "게임을 찾을 수 없습니다"})
+		return game.Game{}, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "게임 조회 실패"})
+		return game.Game{}, false
+	}
+	return g, true
+}
@@ -2,37 +2,195 @@
 package api
 
 import (
+	"context"
+	"time"
+
 	"github.com/gin-gonic/gin"
 
+	"games/backend/anticheat"
+	"games/backend/authsession"
+	"games/backend/battle"
+	"games/backend/config"
+	"games/backend/db"
+	"games/backend/friend"
+	"games/backend/game"
+	"games/backend/gamesession"
+	"games/backend/jwtkeys"
 	"games/backend/middleware"
+	"games/backend/pubsub"
+	"games/backend/rating"
+	"games/backend/replay"
+	"games/backend/revocation"
+	"games/backend/score"
+	"games/backend/season"
+	"games/backend/spectate"
 )
 
-// SetupRoutes 함수는 애플리케이션 API 라우트를 설정합니다.
-func SetupRoutes(router *gin.Engine) {
+// appConfig 서버 설정입니다. (SetupRoutes에서 설정)
+var appConfig *config.Config
+
+// jwtKeys 액세스 토큰 서명/검증 키 묶음입니다.
+var jwtKeys *jwtkeys.Ring
+
+// authSessionService 기기별 로그인 세션과 리프레시 토큰을 관리하는 서비스입니다.
+var authSessionService *authsession.Service
+
+// tokenRevocations 로그아웃으로 폐기한 액세스 토큰 저장소입니다. AuthMiddleware가 요청마다 확인합니다.
+var tokenRevocations *revocation.Store
+
+// scoreService 모든 게임 점수 API가 공유하는 점수 서비스입니다.
+var scoreService *score.Service
+
+// friendService 친구 API와 친구 리더보드가 공유하는 친구 서비스입니다.
+var friendService *friend.Service
+
+// sessionService 점수 제출 전에 게임을 시작하는 게임 세션 서비스입니다.
+var sessionService *gamesession.Service
+
+// anticheatService 점수 저장 전 부정행위 검사와 의심 점수 기록을 담당하는 서비스입니다.
+var anticheatService *anticheat.Service
+
+// replayService 테트리스 리플레이 저장/조회 서비스입니다.
+var replayService *replay.Service
+
+// ratingService 대전 결과로 갱신되는 Elo 레이팅 서비스입니다.
+var ratingService *rating.Service
+
+// battleHub 1대1 테트리스 대전 참가자를 레이팅이 비슷한 두 명씩 묶는 허브입니다.
+var battleHub *battle.Hub
+
+// leaderboardHub 새 최고 점수로 바뀐 상위 순위를 실시간 리더보드(SSE) 구독자에게 나눠 보내는 허브입니다. 주제는 게임 키입니다.
+var leaderboardHub *pubsub.Hub[*leaderboardSnapshot]
+
+// spectateHub 진행 중인 테트리스 게임의 중계와 관전자를 관리하는 허브입니다.
+var spectateHub *spectate.Hub
+
+// seasonService 시즌 API와 시즌 확정 작업이 공유하는 시즌 서비스입니다.
+var seasonService *season.Service
+
+// seasonCloseInterval 종료된 시즌을 확인해 최종 순위를 보관하는 주기입니다.
+const seasonCloseInterval = time.Minute
+
+// revocationPruneInterval 만료된 토큰의 폐기 기록을 정리하는 주기입니다.
+const revocationPruneInterval = 10 * time.Minute
+
+// jwtKeysReloadInterval JWT 키 디렉토리를 다시 읽어 새로 추가한 키를 반영하는 주기입니다.
+const jwtKeysReloadInterval = 5 * time.Minute
+
+// SetupRoutes 함수는 애플리케이션 API 라우트를 설정합니다. db.InitDB 이후에 호출해야 합니다.
+// cfg는 config.Load로 검증된 설정이고, keys는 액세스 토큰 서명/검증 키 묶음입니다.
+func SetupRoutes(router *gin.Engine, cfg *config.Config, keys *jwtkeys.Ring) {
+	appConfig = cfg
+	jwtKeys = keys
+	authSessionService = authsession.NewService(db.DB, cfg.Auth.RefreshTokenTTL)
+	tokenRevocations = revocation.NewStore(db.DB)
+	middleware.Revocations = tokenRevocations
+	scoreService = score.NewService(db.DB, game.Default)
+	// 시즌 최종 순위는 서버 기본 순위 방식으로 보관합니다. (config.Load에서 검증된 값)
+	rankMode, _ := score.ParseRankMode(cfg.Leaderboard.Ranking)
+	seasonService = season.NewService(db.DB, scoreService, rankMode)
+	friendService = friend.NewService(db.DB)
+	sessionService = gamesession.NewService(db.DB, []byte(cfg.Auth.GameSessionSecret))
+	anticheatService = anticheat.NewService(db.DB)
+	replayService = replay.NewService(db.DB)
+	ratingService = rating.NewService(db.DB)
+	battleHub = battle.NewHub(recordBattle)
+	leaderboardHub = pubsub.NewHub[*leaderboardSnapshot]()
+	spectateHub = spectate.NewHub()
+
 	// 인증 불필요 API
 	router.POST("/signup", SignupHandler)
 	router.POST("/login", LoginHandler)
+	router.POST("/auth/refresh", RefreshTokenHandler)
+
+	// 액세스 토큰 검증용 공개키 (다른 서비스가 비밀키 없이 토큰을 검증할 수 있도록)
+	router.GET("/.well-known/jwks.json", JWKSHandler)
+
+	// 게임 카탈로그
+	router.GET("/games", ListGamesHandler)
+	router.GET("/games/:slug", GetGameHandler)
+	router.GET("/games/:slug/leaderboard", middleware.AuthWhen(keys, friendsScope), GetGameLeaderboardHandler)
+
+	// 테트리스 랭킹 조회는 인증 없이 가능하게 설정 (친구 랭킹 scope=friends만 인증 필요)
+	router.GET("/tetris/leaderboard", middleware.AuthWhen(keys, friendsScope), GetTetrisLeaderboardHandler)
+	router.GET("/tetris/leaderboard/stream", middleware.Feature(cfg.Features.LiveLeaderboard), StreamTetrisLeaderboardHandler)
+	router.GET("/tetris/replays/:id", GetTetrisReplayHandler)
+	router.GET("/tetris/live", middleware.Feature(cfg.Features.Spectate), ListLiveTetrisHandler)
+	router.GET("/tetris/ratings/leaderboard", GetTetrisRatingLeaderboardHandler)
+
+	// 게임 관전 (WebSocket, 본인 게임 중계(mode=broadcast)만 인증 필요, token 쿼리 파라미터로도 인증 가능)
+	router.GET("/ws/tetris/watch/:userID", middleware.Feature(cfg.Features.Spectate), middleware.AuthWhen(keys, broadcasting), WatchTetrisHandler)
+
+	// 시즌 조회
+	router.GET("/seasons", ListSeasonsHandler)
+	router.GET("/seasons/:id", GetSeasonHandler)
+	router.GET("/seasons/:id/leaderboard", GetSeasonLeaderboardHandler)
+	router.GET("/seasons/:id/results", GetSeasonResultsHandler)
 
-	// 테트리스 랭킹 조회는 인증 없이 가능하게 설정
-	router.GET("/tetris/leaderboard", GetTetrisLeaderboardHandler)
+	// 로그아웃 (Authorization 헤더가 있으면 인증, 액세스 토큰이 만료되었으면 본문의 refresh_token만으로 로그아웃)
+	router.POST("/auth/logout", middleware.AuthWhen(keys, hasAuthorization), LogoutHandler)
 
 	// 인증 필요 API 그룹
 	auth := router.Group("/")
-	auth.Use(middleware.AuthMiddleware())
+	auth.Use(middleware.AuthMiddleware(keys))
 	{
 		// 사용자 관련 API
 		auth.GET("/user", GetUserHandler)
 
+		// 로그인 세션(기기) 관리
+		auth.GET("/auth/sessions", ListAuthSessionsHandler)
+		auth.DELETE("/auth/sessions/:id", RevokeAuthSessionHandler)
+
+		// 게임별 점수 제출
+		auth.POST("/games/:slug/scores", SubmitGameScoreHandler)
+
 		// 테트리스 관련 API
+		auth.POST("/tetris/sessions", StartTetrisSessionHandler)
 		auth.POST("/tetris/score", UpdateTetrisScoreHandler)
 		auth.GET("/tetris/user/score", GetUserTetrisScoreHandler)
+		auth.GET("/tetris/user/games", GetUserTetrisGamesHandler)
+		auth.GET("/tetris/leaderboard/around-me", GetTetrisLeaderboardAroundMeHandler)
+		auth.GET("/tetris/ratings/me", GetUserTetrisRatingHandler)
+
+		// 실시간 대전 (WebSocket, token 쿼리 파라미터로도 인증 가능)
+		auth.GET("/ws/tetris/battle", middleware.Feature(cfg.Features.Battle), TetrisBattleHandler)
+
+		// 친구 관련 API
+		auth.GET("/friends", ListFriendsHandler)
+		auth.POST("/friends/requests", SendFriendRequestHandler)
+		auth.POST("/friends/requests/:userID/accept", AcceptFriendRequestHandler)
+		auth.POST("/friends/requests/:userID/decline", DeclineFriendRequestHandler)
+		auth.DELETE("/friends/:userID", RemoveFriendHandler)
 
-		// 기존 점수 API (이전 버전 호환성을 위해 유지)
-		auth.POST("/scores", UpdateScoreHandler)
-		auth.GET("/user/scores", GetUserScoreHandler)
+		// 기존 점수 API (이전 버전 호환성을 위해 유지, 테트리스 점수 저장소를 사용)
+		auth.POST("/scores", middleware.Deprecated("/tetris/score"), UpdateScoreHandler)
+		auth.GET("/user/scores", middleware.Deprecated("/tetris/user/score"), GetUserScoreHandler)
+
+		// 관리자 API
+		admin := auth.Group("/admin")
+		admin.Use(middleware.AdminMiddleware())
+		{
+			admin.PUT("/games/:slug", UpdateGameHandler)
+			admin.POST("/seasons", CreateSeasonHandler)
+			admin.GET("/suspicious-scores", ListSuspiciousScoresHandler)
+		}
 	}
 }
 
+// StartBackgroundJobs 함수는 종료된 시즌 확정, 대전 허브, 만료된 토큰 폐기 기록 정리, JWT 키 다시 읽기 등 백그라운드 작업을 시작합니다. SetupRoutes 이후에 호출해야 합니다.
+// ctx가 끝나면 실시간 리더보드 구독과 게임 중계/관전 연결도 모두 닫습니다.
+func StartBackgroundJobs(ctx context.Context) {
+	go seasonService.Run(ctx, seasonCloseInterval)
+	go battleHub.Run(ctx)
+	go tokenRevocations.Run(ctx, revocationPruneInterval)
+	go jwtKeys.Run(ctx, jwtKeysReloadInterval)
+	go func() {
+		<-ctx.Done()
+		leaderboardHub.Close()
+		spectateHub.Close()
+	}()
+}
+
 // GetUserHandler 함수는 현재 로그인한 사용자 정보를 반환합니다.
 func GetUserHandler(c *gin.Context) {
 	userID := c.MustGet("userID").(int)
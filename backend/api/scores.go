@@ -3,15 +3,18 @@ package api
 
 import (
 	"net/http"
-	"time"
 
 	"github.com/gin-gonic/gin"
 
-	"games/backend/db"
 	"games/backend/db/models"
+	"games/backend/score"
 )
 
-// UpdateScoreHandler 함수는 점수를 업데이트합니다.
+// 레거시 점수 API는 테트리스 점수 서비스를 그대로 사용하는 호환용 핸들러입니다.
+// 새 클라이언트는 /tetris/score, /tetris/user/score, /tetris/leaderboard를 사용해야 합니다.
+
+// UpdateScoreHandler 함수는 점수를 업데이트합니다. (레거시, POST /tetris/score와 같은 저장소 사용)
+// 리플레이 없이 제출된 점수는 검증할 수 없으므로 본문은 POST /tetris/score와 같고, 같은 세션과 리플레이 검사를 거칩니다.
 func UpdateScoreHandler(c *gin.Context) {
 	// 사용자 ID 가져오기 (JWT에서 추출)
 	userID, exists := c.Get("userID")
@@ -21,80 +24,54 @@ func UpdateScoreHandler(c *gin.Context) {
 	}
 
 	// 점수 데이터 바인딩
-	var req models.ScoreRequest
+	var req models.TetrisScoreRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"message": "유효하지 않은 점수 데이터"})
 		return
 	}
 
-	// 현재 사용자의 최고 점수 가져오기
-	var currentScore int
-	err := db.DB.QueryRow("SELECT score FROM users WHERE id = $1", userID).Scan(&currentScore)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"message": "사용자 점수 조회 실패"})
-		return
-	}
-
-	// 게임 기록 저장
-	_, err = db.DB.Exec(
-		"INSERT INTO game_records (user_id, score, lines, level, played_at) VALUES ($1, $2, $3, $4, $5)",
-		userID, req.Score, req.Lines, req.Level, time.Now(),
-	)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"message": "게임 기록 저장 실패"})
+	// 게임 기록 저장 및 최고 점수 갱신
+	submission, _, ok := submitTetrisScore(c, userID.(int), &req)
+	if !ok {
 		return
 	}
-
-	// 최고 점수 갱신 여부 확인 및 업데이트
-	isNewHighScore := false
-	if req.Score > currentScore {
-		_, err = db.DB.Exec("UPDATE users SET score = $1 WHERE id = $2", req.Score, userID)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"message": "점수 업데이트 실패"})
-			return
-		}
-		isNewHighScore = true
+	if submission.IsNewBest {
+		publishTetrisLeaderboard()
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"message":      "점수가 저장되었습니다",
-		"newHighScore": isNewHighScore,
+		"newHighScore": submission.IsNewBest,
 	})
 }
 
-// GetHighScoresHandler 함수는 상위 점수 목록을 반환합니다.
+// GetHighScoresHandler 함수는 상위 점수 목록을 반환합니다. (레거시, GET /tetris/leaderboard와 같은 저장소 사용)
 func GetHighScoresHandler(c *gin.Context) {
 	// 상위 10개 고득점 목록 가져오기
-	rows, err := db.DB.Query(`
-		SELECT u.username, u.nickname, u.score, gr.lines, gr.level, gr.played_at 
-		FROM users u
-		JOIN game_records gr ON u.id = gr.user_id
-		ORDER BY u.score DESC
-		LIMIT 10
-	`)
+	mode, _ := score.ParseRankMode(appConfig.Leaderboard.Ranking)
+	leaderboard, _, err := scoreService.Leaderboard(tetrisGame, score.LeaderboardQuery{Limit: 10, Mode: mode})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"message": "고득점 목록을 불러오는데 실패했습니다"})
 		return
 	}
-	defer rows.Close()
 
 	// 결과 조합
-	var highScores []models.ScoreResponse
-	for rows.Next() {
-		var score models.ScoreResponse
-		var playedAt time.Time
-		if err := rows.Scan(&score.Username, &score.Nickname, &score.Score, &score.Lines, &score.Level, &playedAt); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"message": "데이터 처리 중 오류가 발생했습니다"})
-			return
-		}
-		score.Date = playedAt.Format("2006-01-02 15:04:05")
-		highScores = append(highScores, score)
+	highScores := make([]models.ScoreResponse, 0, len(leaderboard))
+	for _, entry := range leaderboard {
+		highScores = append(highScores, models.ScoreResponse{
+			Username: entry.Username,
+			Nickname: entry.Nickname,
+			Score:    entry.Score,
+			Lines:    entry.Lines,
+			Level:    entry.Level,
+			Date:     entry.UpdatedAt.Format("2006-01-02 15:04:05"),
+		})
 	}
 
 	c.JSON(http.StatusOK, gin.H{"highScores": highScores})
 }
 
-// GetUserScoreHandler 함수는 현재 사용자의 점수 정보를 반환합니다.
+// GetUserScoreHandler 함수는 현재 사용자의 점수 정보를 반환합니다. (레거시, GET /tetris/user/score와 같은 저장소 사용)
 func GetUserScoreHandler(c *gin.Context) {
 	// JWT 토큰에서 사용자 식별
 	userID, exists := c.Get("userID")
@@ -103,47 +80,41 @@ func GetUserScoreHandler(c *gin.Context) {
 		return
 	}
 
-	// 사용자 점수 정보 조회
-	var username string
-	var nickname string
-	var score int
-	err := db.DB.QueryRow("SELECT username, nickname, score FROM users WHERE id = $1", userID).Scan(&username, &nickname, &score)
-	if err != nil {
+	// 사용자 최고 점수 조회 (기록이 없으면 0점)
+	username := c.GetString("username")
+	nickname := c.GetString("nickname")
+	highScore := 0
+	best, err := scoreService.Best(tetrisGame, userID.(int))
+	switch {
+	case err == nil:
+		highScore = best.Score
+	case err != score.ErrNoRecord:
 		c.JSON(http.StatusInternalServerError, gin.H{"message": "사용자 정보를 불러오는데 실패했습니다"})
 		return
 	}
 
 	// 최근 게임 기록 조회
-	rows, err := db.DB.Query(`
-		SELECT score, lines, level, played_at 
-		FROM game_records 
-		WHERE user_id = $1 
-		ORDER BY played_at DESC
-		LIMIT 5
-	`, userID)
+	plays, err := scoreService.History(tetrisGame, userID.(int), score.HistoryQuery{Limit: 5})
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"message": "게임 기록을 불러오는데 실패했습니다"})
 		return
 	}
-	defer rows.Close()
 
 	// 결과 조합
-	var gameHistory []models.ScoreResponse
-	for rows.Next() {
-		var record models.ScoreResponse
-		record.Username = username
-		record.Nickname = nickname
-		var playedAt time.Time
-		if err := rows.Scan(&record.Score, &record.Lines, &record.Level, &playedAt); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"message": "데이터 처리 중 오류가 발생했습니다"})
-			return
-		}
-		record.Date = playedAt.Format("2006-01-02 15:04:05")
-		gameHistory = append(gameHistory, record)
+	gameHistory := make([]models.ScoreResponse, 0, len(plays))
+	for _, play := range plays {
+		gameHistory = append(gameHistory, models.ScoreResponse{
+			Username: username,
+			Nickname: nickname,
+			Score:    play.Score,
+			Lines:    play.Lines,
+			Level:    play.Level,
+			Date:     play.PlayedAt.Format("2006-01-02 15:04:05"),
+		})
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"highScore":   score,
+		"highScore":   highScore,
 		"username":    username,
 		"nickname":    nickname,
 		"gameHistory": gameHistory,
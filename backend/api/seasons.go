@@ -0,0 +1,146 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"games/backend/db/models"
+	"games/backend/game"
+	"games/backend/season"
+)
+
+// CreateSeasonHandler 관리자가 새 시즌을 생성합니다. game을 생략하면 테트리스 시즌입니다.
+func CreateSeasonHandler(c *gin.Context) {
+	var req models.SeasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "유효하지 않은 시즌 데이터 (name, starts_at, ends_at 필수)"})
+		return
+	}
+
+	if req.Game == "" {
+		req.Game = tetrisGame
+	}
+	if _, ok := game.Default.Get(req.Game); !ok {
+		c.JSON(http.StatusNotFound, gin.H{"message": "게임을 찾을 수 없습니다"})
+		return
+	}
+	if !req.EndsAt.After(req.StartsAt) {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "종료 시각은 시작 시각보다 늦어야 합니다"})
+		return
+	}
+	if req.TopN == 0 {
+		req.TopN = season.DefaultTopN
+	}
+	if req.TopN < 0 || req.TopN > season.MaxTopN {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "top_n은 1 이상 " + strconv.Itoa(season.MaxTopN) + " 이하여야 합니다"})
+		return
+	}
+
+	created, err := seasonService.Create(req)
+	if err == season.ErrOverlap {
+		c.JSON(http.StatusConflict, gin.H{"message": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "시즌 생성 실패"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// ListSeasonsHandler 시즌 목록을 최신 시즌부터 반환합니다. game 파라미터로 게임을 지정할 수 있습니다.
+func ListSeasonsHandler(c *gin.Context) {
+	seasons, err := seasonService.List(c.Query("game"))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "시즌 목록 조회 실패"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"seasons": seasons})
+}
+
+// GetSeasonHandler 시즌 정보를 반환합니다.
+func GetSeasonHandler(c *gin.Context) {
+	s, ok := seasonParam(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, s)
+}
+
+// GetSeasonLeaderboardHandler 시즌 기간 동안의 리더보드를 조회합니다. 진행 중인 시즌은 실시간 순위입니다.
+func GetSeasonLeaderboardHandler(c *gin.Context) {
+	s, ok := seasonParam(c)
+	if !ok {
+		return
+	}
+
+	limit, offset := paginationParams(c, 10)
+	mode, err := rankModeParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	leaderboard, total, err := seasonService.Leaderboard(s, limit, offset, mode)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "시즌 리더보드 조회 실패"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"season":      s,
+		"ranking":     mode,
+		"leaderboard": leaderboard,
+		"pagination": gin.H{
+			"total":  total,
+			"limit":  limit,
+			"offset": offset,
+		},
+	})
+}
+
+// GetSeasonResultsHandler 종료된 시즌의 보관된 최종 순위(상위 top_n)를 반환합니다.
+func GetSeasonResultsHandler(c *gin.Context) {
+	s, ok := seasonParam(c)
+	if !ok {
+		return
+	}
+
+	results, err := seasonService.Results(s)
+	if err == season.ErrNotClosed {
+		c.JSON(http.StatusConflict, gin.H{"message": err.Error(), "status": s.Status})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "시즌 결과 조회 실패"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"season":  s,
+		"results": results,
+	})
+}
+
+// seasonParam 함수는 경로의 :id 시즌을 조회합니다. 실패하면 응답을 작성하고 false를 반환합니다.
+func seasonParam(c *gin.Context) (*models.Season, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil || id <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "잘못된 시즌 ID입니다"})
+		return nil, false
+	}
+
+	s, err := seasonService.Get(id)
+	if err == season.ErrNotFound {
+		c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+		return nil, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "시즌 조회 실패"})
+		return nil, false
+	}
+	return s, true
+}
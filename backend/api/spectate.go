@@ -0,0 +1,49 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// broadcastMode 관전 WebSocket에서 본인 게임을 중계할 때 쓰는 mode 파라미터 값입니다.
+const broadcastMode = "broadcast"
+
+// broadcasting 함수는 게임 중계 요청인지 반환합니다. (라우트에서 인증 적용 여부 결정에 사용, 관전은 로그인 없이 가능)
+func broadcasting(c *gin.Context) bool {
+	return c.Query("mode") == broadcastMode
+}
+
+// WatchTetrisHandler WebSocket으로 사용자의 진행 중인 테트리스 게임을 관전하거나, mode=broadcast면 본인 게임을 중계합니다.
+// 중계는 라우트의 middleware.AuthWhen(broadcasting)으로 인증된 본인만 할 수 있습니다.
+// 관전자는 연결 직후 가장 최근 보드를 받고, 이후 중계자가 보내는 보드를 실시간으로 받습니다.
+func WatchTetrisHandler(c *gin.Context) {
+	targetID, ok := userIDParam(c)
+	if !ok {
+		return
+	}
+
+	isBroadcast := broadcasting(c)
+	if isBroadcast && c.MustGet("userID").(int) != targetID {
+		c.JSON(http.StatusForbidden, gin.H{"message": "본인 게임만 중계할 수 있습니다"})
+		return
+	}
+
+	// 업그레이드에 실패하면 업그레이더가 오류 응답을 보냄
+	conn, err := wsUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+
+	// 연결이 끝날 때까지 반환하지 않음
+	if isBroadcast {
+		spectateHub.Broadcast(conn, targetID, c.MustGet("nickname").(string))
+		return
+	}
+	spectateHub.Watch(conn, targetID)
+}
+
+// ListLiveTetrisHandler 지금 보드를 중계 중인 테트리스 게임 목록을 관전자 수 순서로 반환합니다.
+func ListLiveTetrisHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"live": spectateHub.List()})
+}
@@ -0,0 +1,136 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"games/backend/db/models"
+	"games/backend/score"
+)
+
+// 실시간 리더보드(SSE) 설정입니다.
+const (
+	leaderboardStreamMaxLimit = 50               // 스트림 하나가 받을 수 있는 최대 순위 수
+	leaderboardHeartbeat      = 30 * time.Second // 프록시가 연결을 끊지 않도록 보내는 주석 주기
+)
+
+// leaderboardSnapshot 리더보드 허브로 발행하는 전체 기간 상위 순위 목록입니다.
+// seq는 조회 순서이며, 구독자는 이미 보낸 목록보다 먼저 조회된 목록을 무시합니다.
+type leaderboardSnapshot struct {
+	seq         uint64
+	leaderboard []models.GameScore
+	total       int
+	ranking     score.RankMode
+}
+
+// leaderboardFetch 리더보드 조회와 seq 부여를 직렬화해 나중에 발행된 목록이 항상 최신이 되도록 합니다.
+var leaderboardFetch struct {
+	sync.Mutex
+	seq uint64
+}
+
+// fetchTetrisLeaderboard 함수는 스트림에 보낼 테트리스 상위 순위를 서버 기본 순위 방식으로 조회합니다.
+func fetchTetrisLeaderboard() (*leaderboardSnapshot, error) {
+	leaderboardFetch.Lock()
+	defer leaderboardFetch.Unlock()
+
+	// config.Load에서 검증된 값
+	mode, _ := score.ParseRankMode(appConfig.Leaderboard.Ranking)
+	leaderboard, total, err := scoreService.Leaderboard(tetrisGame, score.LeaderboardQuery{Limit: leaderboardStreamMaxLimit, Mode: mode})
+	if err != nil {
+		return nil, err
+	}
+
+	leaderboardFetch.seq++
+	return &leaderboardSnapshot{seq: leaderboardFetch.seq, leaderboard: leaderboard, total: total, ranking: mode}, nil
+}
+
+// publishTetrisLeaderboard 함수는 새 최고 점수가 기록된 뒤 상위 순위를 다시 조회해 스트림 구독자에게 발행합니다.
+// 점수 제출 응답을 늦추지 않도록 별도 고루틴에서 조회하며, 구독자가 없으면 조회하지 않습니다.
+func publishTetrisLeaderboard() {
+	if leaderboardHub.Subscribers(tetrisGame) == 0 {
+		return
+	}
+	go func() {
+		snapshot, err := fetchTetrisLeaderboard()
+		if err != nil {
+			log.Printf("실시간 리더보드 조회 실패: %v", err)
+			return
+		}
+		leaderboardHub.Publish(tetrisGame, snapshot)
+	}()
+}
+
+// StreamTetrisLeaderboardHandler 테트리스 전체 기간 상위 limit명(기본 10, 최대 50)을 Server-Sent Events로 보냅니다.
+// 연결 직후 현재 목록을 보내고, 이후 새 최고 점수로 상위 limit명이 바뀔 때마다 leaderboard 이벤트를 보냅니다.
+// 클라이언트 연결이 끊기면 구독을 해지하고 핸들러 고루틴이 끝납니다.
+func StreamTetrisLeaderboardHandler(c *gin.Context) {
+	limit, _ := paginationParams(c, 10)
+	limit = min(limit, leaderboardStreamMaxLimit)
+
+	// 구독을 먼저 해야 현재 목록 조회와 구독 사이의 갱신을 놓치지 않음
+	sub := leaderboardHub.Subscribe(tetrisGame)
+	defer sub.Close()
+
+	current, err := fetchTetrisLeaderboard()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "리더보드 조회 실패"})
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("X-Accel-Buffering", "no") // nginx 응답 버퍼링 끄기
+	c.Status(http.StatusOK)
+
+	var lastSeq uint64
+	var lastTop []byte
+	send := func(snapshot *leaderboardSnapshot) {
+		if snapshot.seq <= lastSeq {
+			return
+		}
+		lastSeq = snapshot.seq
+
+		// 이 스트림의 상위 limit명이 그대로면 보내지 않음
+		top := snapshot.leaderboard[:min(limit, len(snapshot.leaderboard))]
+		encoded, err := json.Marshal(top)
+		if err != nil || bytes.Equal(encoded, lastTop) {
+			return
+		}
+		lastTop = encoded
+
+		c.SSEvent("leaderboard", gin.H{
+			"ranking":     snapshot.ranking,
+			"leaderboard": json.RawMessage(encoded),
+			"total":       snapshot.total,
+		})
+		c.Writer.Flush()
+	}
+	send(current)
+
+	heartbeat := time.NewTicker(leaderboardHeartbeat)
+	defer heartbeat.Stop()
+
+	ctx := c.Request.Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case snapshot, ok := <-sub.C():
+			if !ok {
+				return // 서버 종료
+			}
+			send(snapshot)
+		case <-heartbeat.C:
+			fmt.Fprint(c.Writer, ": ping\n\n")
+			c.Writer.Flush()
+		}
+	}
+}
@@ -2,17 +2,47 @@ package api
 
 import (
 	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 
-	"games/backend/db"
+	"games/backend/anticheat"
 	"games/backend/db/models"
+	"games/backend/game"
+	"games/backend/gamesession"
+	"games/backend/replay"
+	"games/backend/score"
+	"games/backend/tetris"
 )
 
-// UpdateTetrisScoreHandler 테트리스 최고 점수를 업데이트합니다.
+// tetrisGame 점수 서비스에서 테트리스를 구분하는 게임 키입니다.
+const tetrisGame = game.Tetris
+
+// StartTetrisSessionHandler 새 테트리스 게임 세션을 시작하고 세션 ID와 난수 시드를 발급합니다.
+// 클라이언트는 발급받은 시드로 7-bag과 가비지 빈칸을 만들고, 점수 제출 시 세션 ID와 서명을 함께 보냅니다.
+func StartTetrisSessionHandler(c *gin.Context) {
+	userID := c.MustGet("userID").(int)
+
+	session, err := sessionService.Start(tetrisGame, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "게임 세션 시작 실패"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, session)
+}
+
+// UpdateTetrisScoreHandler 끝난 테트리스 게임을 기록하고 최고 점수를 업데이트합니다.
+// 요청은 본인이 시작한 열린 게임 세션을 참조해야 하며, 세션은 한 번 제출하면 닫힙니다.
+// 서버 내부 오류(500)로 점수를 저장하지 못하면 세션을 다시 열어 같은 세션으로 다시 제출할 수 있습니다.
+// 요청의 리플레이(시드와 입력 기록)를 서버 엔진으로 다시 재생해 점수, 줄 수, 레벨이 다르면 422로 거절합니다.
 func UpdateTetrisScoreHandler(c *gin.Context) {
 	// 사용자 ID 가져오기 (JWT에서 추출)
 	userID, exists := c.Get("userID")
@@ -28,165 +58,296 @@ func UpdateTetrisScoreHandler(c *gin.Context) {
 		return
 	}
 
-	// 현재 최고 점수 조회
-	var currentScore int
-	var hasRecord bool // exists 대신 다른 변수명 사용
-	err := db.DB.QueryRow(
-		`SELECT score, TRUE FROM tetris_scores WHERE user_id = $1`,
-		userID,
-	).Scan(&currentScore, &hasRecord)
+	// 응답 순위 방식 (기록을 저장하기 전에 검증)
+	mode, err := rankModeParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
 
-	if err != nil && err != sql.ErrNoRows {
-		c.JSON(http.StatusInternalServerError, gin.H{"message": "최고 점수 조회 실패"})
+	submission, replayed, ok := submitTetrisScore(c, userID.(int), &req)
+	if !ok {
 		return
 	}
 
-	// 새 점수가 더 높은지 확인
-	isNewHighScore := false
-	if err == sql.ErrNoRows || req.Score > currentScore {
-		isNewHighScore = true
-	} else {
-		// 최고 점수가 아니면 저장하지 않고 바로 응답
+	// 요청한 경우 검증된 리플레이를 압축해 보관 (실패해도 점수는 이미 저장됨)
+	hasReplay := false
+	if req.SaveReplay {
+		if err := saveTetrisReplay(userID.(int), submission.PlayID, req.Replay); err != nil {
+			log.Printf("플레이 기록 %d의 리플레이 저장 실패: %v", submission.PlayID, err)
+		} else {
+			hasReplay = true
+		}
+	}
+
+	// 최고 점수가 아니면 순위 없이 바로 응답
+	if !submission.IsNewBest {
 		c.JSON(http.StatusOK, gin.H{
 			"message":          "기존 최고 점수가 더 높습니다",
-			"currentHighScore": currentScore,
+			"gameId":           submission.PlayID,
+			"currentHighScore": submission.BestScore,
 			"isNewHighScore":   false,
+			"hasReplay":        hasReplay,
 		})
 		return
 	}
 
-	// 최고 점수인 경우에만 DB 업데이트
-	if isNewHighScore {
-		now := time.Now()
-
-		if err == sql.ErrNoRows {
-			// 첫 기록인 경우 INSERT
-			_, err = db.DB.Exec(
-				`INSERT INTO tetris_scores 
-				(user_id, score, lines, level, created_at, updated_at)
-				VALUES ($1, $2, $3, $4, $5, $6)`,
-				userID, req.Score, req.Lines, req.Level, now, now,
-			)
-		} else {
-			// 기존 기록이 있는 경우 UPDATE
-			_, err = db.DB.Exec(
-				`UPDATE tetris_scores 
-				SET score = $1, lines = $2, level = $3, updated_at = $4
-				WHERE user_id = $5`,
-				req.Score, req.Lines, req.Level, now, userID,
-			)
-		}
-
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"message": "점수 업데이트 실패"})
-			return
-		}
-	}
+	// 실시간 리더보드 구독자에게 바뀐 상위 순위 발행
+	publishTetrisLeaderboard()
 
 	// 랭킹 정보 조회 (현재 사용자의 순위)
-	var rank int
-	err = db.DB.QueryRow(
-		`SELECT COUNT(*) + 1 
-		FROM tetris_scores
-		WHERE score > $1`,
-		req.Score,
-	).Scan(&rank)
-
+	rank, err := scoreService.Rank(tetrisGame, replayed.Score, mode)
 	if err != nil {
 		rank = 0 // 순위 조회 실패 시 0으로 설정
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"message":        "점수가 업데이트되었습니다",
-		"isNewHighScore": isNewHighScore,
+		"gameId":         submission.PlayID,
+		"isNewHighScore": true,
 		"rank":           rank,
+		"hasReplay":      hasReplay,
 	})
 }
 
-// GetTetrisLeaderboardHandler 테트리스 리더보드(랭킹) 정보를 조회합니다.
-func GetTetrisLeaderboardHandler(c *gin.Context) {
-	// 페이지네이션 파라미터
-	limit := 10
-	offset := 0
+// releaseTetrisSession 함수는 서버 내부 오류로 저장하지 못한 제출의 게임 세션을 다시 엽니다.
+func releaseTetrisSession(sessionID string) {
+	if err := sessionService.Release(sessionID); err != nil {
+		log.Printf("게임 세션 %s 다시 열기 실패: %v", sessionID, err)
+	}
+}
 
-	// 쿼리 파라미터 읽기
-	if limitParam := c.Query("limit"); limitParam != "" {
-		if val, err := strconv.Atoi(limitParam); err == nil && val > 0 {
-			limit = val
-		}
+// saveTetrisReplay 함수는 검증을 통과한 리플레이를 압축해 플레이 기록에 연결합니다.
+func saveTetrisReplay(userID int, playID int64, r *tetris.Replay) error {
+	data, size, err := tetris.EncodeReplay(r)
+	if err != nil {
+		return err
 	}
+	return replayService.Save(playID, tetrisGame, userID, r.Version, data, size)
+}
 
-	if offsetParam := c.Query("offset"); offsetParam != "" {
-		if val, err := strconv.Atoi(offsetParam); err == nil && val >= 0 {
-			offset = val
-		}
+// GetTetrisReplayHandler 저장된 테트리스 리플레이를 플레이 정보와 함께 반환합니다. 리플레이 ID는 플레이 기록 ID입니다.
+// 반환된 replay는 점수 제출 시 보낸 형식과 같아 브라우저 게임에서 그대로 재생할 수 있습니다.
+func GetTetrisReplayHandler(c *gin.Context) {
+	playID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || playID <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "유효하지 않은 리플레이 ID"})
+		return
 	}
 
-	// 리더보드 쿼리
-	rows, err := db.DB.Query(
-		`SELECT 
-			ts.user_id, 
-			u.username, 
-			u.nickname, 
-			ts.score, 
-			ts.lines, 
-			ts.level, 
-			ts.created_at,
-			ts.updated_at
-		FROM tetris_scores ts
-		JOIN users u ON ts.user_id = u.id
-		ORDER BY ts.score DESC
-		LIMIT $1 OFFSET $2`,
-		limit, offset,
-	)
+	stored, err := replayService.Get(tetrisGame, playID)
+	if err == replay.ErrNotFound {
+		c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "리플레이 조회 실패"})
+		return
+	}
 
+	decoded, err := tetris.DecodeReplay(stored.Data)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"message": "리더보드 조회 실패"})
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "리플레이 데이터 처리 실패"})
 		return
 	}
-	defer rows.Close()
-
-	// 결과 조합
-	var leaderboard []models.TetrisScore
-	rank := offset + 1 // 시작 순위 계산
-
-	for rows.Next() {
-		var score models.TetrisScore
-		if err := rows.Scan(
-			&score.UserID,
-			&score.Username,
-			&score.Nickname,
-			&score.Score,
-			&score.Lines,
-			&score.Level,
-			&score.CreatedAt,
-			&score.UpdatedAt,
-		); err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{"message": "데이터 처리 중 오류 발생"})
-			return
-		}
 
-		leaderboard = append(leaderboard, score)
-		rank++
+	// 저장된 리플레이는 바뀌지 않으므로 캐시 허용
+	c.Header("Cache-Control", "public, max-age=86400")
+	c.JSON(http.StatusOK, gin.H{
+		"play":   stored,
+		"replay": decoded,
+	})
+}
+
+// submitTetrisScore 함수는 게임 세션을 닫고 리플레이를 검증한 뒤 플레이 기록을 저장합니다.
+// POST /tetris/score와 레거시 POST /scores가 같은 검사를 거치도록 함께 사용하며, 실패하면 응답을 작성하고 false를 반환합니다.
+func submitTetrisScore(c *gin.Context, userID int, req *models.TetrisScoreRequest) (*score.Submission, *tetris.Result, bool) {
+	// 게임 세션 확인 및 닫기 (같은 세션으로 다시 제출할 수 없음)
+	claim, err := sessionService.Claim(tetrisGame, userID, req.SessionID, req.SessionSignature)
+	switch {
+	case errors.Is(err, gamesession.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"message": err.Error()})
+		return nil, nil, false
+	case errors.Is(err, gamesession.ErrFinished):
+		c.JSON(http.StatusConflict, gin.H{"message": err.Error()})
+		return nil, nil, false
+	case errors.Is(err, gamesession.ErrExpired):
+		c.JSON(http.StatusGone, gin.H{"message": err.Error()})
+		return nil, nil, false
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "게임 세션 확인 실패"})
+		return nil, nil, false
+	}
+	if err := claim.CheckSeed(req.Replay.Seed); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": err.Error()})
+		return nil, nil, false
+	}
+
+	// 리플레이를 재생하기 전에 불가능한 결과와 제출 빈도 검사
+	submitted := anticheat.Submission{
+		Score:           req.Score,
+		Lines:           req.Lines,
+		Level:           req.Level,
+		DurationMs:      req.DurationMs,
+		PiecesPlaced:    req.PiecesPlaced,
+		MaxCombo:        req.MaxCombo,
+		GarbageSurvived: req.GarbageSurvived,
+		Elapsed:         claim.Elapsed,
+	}
+	flags := anticheat.Check(submitted)
+	rateFlag, err := anticheatService.CheckRate(tetrisGame, userID)
+	if err != nil {
+		releaseTetrisSession(req.SessionID)
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "제출 빈도 확인 실패"})
+		return nil, nil, false
+	}
+	if rateFlag != nil {
+		flags = append(flags, *rateFlag)
+	}
+	if len(flags) > 0 {
+		rejectSuspiciousScore(c, userID, req.SessionID, submitted, flags)
+		return nil, nil, false
+	}
+
+	// 리플레이를 서버에서 다시 재생해 제출된 점수 검증
+	replayed, err := tetris.Verify(req.Replay, req.Score, req.Lines, req.Level)
+	if errors.Is(err, tetris.ErrReplayMismatch) {
+		rejectSuspiciousScore(c, userID, req.SessionID, submitted, []anticheat.Flag{{Reason: anticheat.ReasonReplayMismatch, Detail: err.Error()}})
+		return nil, nil, false
+	}
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": err.Error()})
+		return nil, nil, false
+	}
+
+	// 리플레이 플레이 시간이 세션 시작 후 실제로 흐른 시간보다 길 수 없음
+	if err := claim.CheckDuration(replayed.DurationMs); err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": err.Error()})
+		return nil, nil, false
+	}
+
+	// 플레이 기록 저장 및 최고 점수 갱신 (기록 값은 클라이언트가 아닌 재생 결과 사용)
+	submission, err := scoreService.Submit(tetrisGame, userID, score.Result{
+		Score:           replayed.Score,
+		Lines:           replayed.Lines,
+		Level:           replayed.Level,
+		DurationMs:      replayed.DurationMs,
+		PiecesPlaced:    replayed.PiecesPlaced,
+		MaxCombo:        replayed.MaxCombo,
+		GarbageSurvived: replayed.GarbageSurvived,
+	})
+	if err != nil {
+		releaseTetrisSession(req.SessionID)
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "점수 업데이트 실패"})
+		return nil, nil, false
+	}
+	if err := sessionService.Attach(req.SessionID, submission.PlayID); err != nil {
+		log.Printf("게임 세션 %s에 플레이 기록 %d 연결 실패: %v", req.SessionID, submission.PlayID, err)
+	}
+	return submission, replayed, true
+}
+
+// rejectSuspiciousScore 함수는 부정행위 검사에 걸린 제출을 suspicious_scores에 저장하고 사유와 함께 422로 응답합니다.
+func rejectSuspiciousScore(c *gin.Context, userID int, sessionID string, sub anticheat.Submission, flags []anticheat.Flag) {
+	if err := anticheatService.Record(tetrisGame, userID, sessionID, sub, flags); err != nil {
+		log.Printf("사용자 %d의 의심 점수 저장 실패: %v", userID, err)
+	}
+
+	reasons := make([]string, 0, len(flags))
+	for _, flag := range flags {
+		reasons = append(reasons, flag.Reason)
+	}
+	c.JSON(http.StatusUnprocessableEntity, gin.H{
+		"message": "비정상적인 점수로 판단되어 저장하지 않았습니다",
+		"reasons": reasons,
+	})
+}
+
+// GetTetrisLeaderboardHandler 테트리스 리더보드(랭킹) 정보를 조회합니다.
+// period 파라미터(daily, weekly, monthly, all)로 일간/주간/월간 랭킹을, scope=friends로 친구 랭킹을 조회할 수 있습니다.
+func GetTetrisLeaderboardHandler(c *gin.Context) {
+	// 페이지네이션 및 기간 파라미터
+	query, period, err := leaderboardParams(c, 10)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	scope, ok := applyScope(c, &query)
+	if !ok {
+		return
 	}
 
-	// 전체 레코드 수 조회 (페이지네이션 정보용)
-	var total int
-	err = db.DB.QueryRow("SELECT COUNT(*) FROM tetris_scores").Scan(&total)
+	leaderboard, total, err := scoreService.Leaderboard(tetrisGame, query)
 	if err != nil {
-		total = 0 // 오류 시 0으로 설정
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "리더보드 조회 실패"})
+		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
+		"period":      periodResponse(period, query),
+		"ranking":     query.Mode,
+		"scope":       scope,
 		"leaderboard": leaderboard,
 		"pagination": gin.H{
 			"total":  total,
-			"limit":  limit,
-			"offset": offset,
+			"limit":  query.Limit,
+			"offset": query.Offset,
 		},
 	})
 }
 
+// GetTetrisLeaderboardAroundMeHandler 현재 사용자 위아래 radius명(기본 5, 최대 50)의 테트리스 순위를 조회합니다.
+func GetTetrisLeaderboardAroundMeHandler(c *gin.Context) {
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "인증이 필요합니다"})
+		return
+	}
+
+	radius := 5
+	if radiusParam := c.Query("radius"); radiusParam != "" {
+		val, err := strconv.Atoi(radiusParam)
+		if err != nil || val < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "radius는 0 이상의 정수여야 합니다"})
+			return
+		}
+		radius = val
+	}
+	if radius > 50 {
+		radius = 50
+	}
+
+	mode, err := rankModeParam(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	around, err := scoreService.Around(tetrisGame, userID.(int), radius, mode)
+	if err == score.ErrNoRecord {
+		// 기록이 없으면 주변 순위도 없습니다.
+		c.JSON(http.StatusOK, gin.H{
+			"hasRecord":   false,
+			"radius":      radius,
+			"ranking":     mode,
+			"leaderboard": []models.TetrisScore{},
+		})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "주변 순위 조회 실패"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"hasRecord":   true,
+		"radius":      radius,
+		"ranking":     mode,
+		"leaderboard": around,
+	})
+}
+
 // GetUserTetrisScoreHandler 특정 사용자의 테트리스 최고 점수를 조회합니다.
 func GetUserTetrisScoreHandler(c *gin.Context) {
 	// JWT 토큰에서 사용자 식별
@@ -196,74 +357,154 @@ func GetUserTetrisScoreHandler(c *gin.Context) {
 		return
 	}
 
-	// 사용자 정보 조회
-	var username, nickname string
-	err := db.DB.QueryRow(
-		"SELECT username, nickname FROM users WHERE id = $1",
-		userID,
-	).Scan(&username, &nickname)
-
+	mode, err := rankModeParam(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"message": "사용자 정보 조회 실패"})
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
 		return
 	}
 
 	// 사용자의 테트리스 최고 점수 조회
-	var score models.TetrisScore
-	score.UserID = userID.(int)
-	score.Username = username
-	score.Nickname = nickname
-
-	err = db.DB.QueryRow(
-		`SELECT score, lines, level, created_at, updated_at
-		FROM tetris_scores 
-		WHERE user_id = $1`,
-		userID,
-	).Scan(
-		&score.Score,
-		&score.Lines,
-		&score.Level,
-		&score.CreatedAt,
-		&score.UpdatedAt,
-	)
-
+	best, err := scoreService.Best(tetrisGame, userID.(int))
+	if err == score.ErrNoRecord {
+		// 기록이 없는 경우
+		c.JSON(http.StatusOK, gin.H{
+			"username":  c.GetString("username"),
+			"nickname":  c.GetString("nickname"),
+			"highScore": 0,
+			"hasRecord": false,
+			"rank":      0,
+		})
+		return
+	}
 	if err != nil {
-		if err == sql.ErrNoRows {
-			// 기록이 없는 경우
-			c.JSON(http.StatusOK, gin.H{
-				"username":  username,
-				"nickname":  nickname,
-				"highScore": 0,
-				"hasRecord": false,
-				"rank":      0,
-			})
-			return
-		}
-
 		c.JSON(http.StatusInternalServerError, gin.H{"message": "점수 조회 실패"})
 		return
 	}
 
 	// 사용자 랭킹 조회
-	var rank int
-	err = db.DB.QueryRow(
-		`SELECT COUNT(*) + 1 
-		FROM tetris_scores 
-		WHERE score > $1`,
-		score.Score,
-	).Scan(&rank)
-
+	rank, err := scoreService.Rank(tetrisGame, best.Score, mode)
 	if err != nil {
 		rank = 0 // 오류 시 0으로 설정
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"username":  username,
-		"nickname":  nickname,
-		"highScore": score.Score,
-		"lines":     score.Lines,
-		"level":     score.Level,
+		"username":  best.Username,
+		"nickname":  best.Nickname,
+		"highScore": best.Score,
+		"lines":     best.Lines,
+		"level":     best.Level,
 		"hasRecord": true,
 		"rank":      rank,
 	})
 }
+
+// GetUserTetrisGamesHandler 현재 사용자의 테트리스 플레이 기록을 최신순으로 조회합니다.
+// 쿼리 파라미터: limit(기본 20, 최대 100), cursor(이전 응답의 nextCursor), from/to(YYYY-MM-DD 또는 RFC3339, to는 미포함)
+func GetUserTetrisGamesHandler(c *gin.Context) {
+	// JWT 토큰에서 사용자 식별
+	userID, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "인증이 필요합니다"})
+		return
+	}
+
+	limit := 20
+	if limitParam := c.Query("limit"); limitParam != "" {
+		if val, err := strconv.Atoi(limitParam); err == nil && val > 0 {
+			limit = val
+		}
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	// 날짜 필터
+	from, err := parseTimeParam(c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "from 날짜 형식이 올바르지 않습니다"})
+		return
+	}
+	to, err := parseTimeParam(c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "to 날짜 형식이 올바르지 않습니다"})
+		return
+	}
+
+	// 다음 페이지 존재 여부 확인을 위해 하나 더 조회
+	query := score.HistoryQuery{Limit: limit + 1, From: from, To: to}
+
+	// 커서 (마지막으로 받은 기록의 played_at, id)
+	if cursorParam := c.Query("cursor"); cursorParam != "" {
+		t, id, err := decodeCursor(cursorParam)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"message": "유효하지 않은 커서입니다"})
+			return
+		}
+		query.Before = sql.NullTime{Time: t, Valid: true}
+		query.BeforeID = id
+	}
+
+	games, err := scoreService.History(tetrisGame, userID.(int), query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "플레이 기록 조회 실패"})
+		return
+	}
+
+	// 다음 페이지 커서 생성
+	var nextCursor string
+	if len(games) > limit {
+		games = games[:limit]
+		last := games[len(games)-1]
+		nextCursor = encodeCursor(last.PlayedAt, last.ID)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"games":      games,
+		"nextCursor": nextCursor,
+		"hasMore":    nextCursor != "",
+	})
+}
+
+// parseTimeParam 함수는 YYYY-MM-DD 또는 RFC3339 형식의 쿼리 파라미터를 해석합니다. 빈 값이면 NULL을 반환합니다.
+func parseTimeParam(value string) (sql.NullTime, error) {
+	if value == "" {
+		return sql.NullTime{}, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return sql.NullTime{Time: t, Valid: true}, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return sql.NullTime{}, err
+	}
+	return sql.NullTime{Time: t.In(time.Local), Valid: true}, nil
+}
+
+// encodeCursor 함수는 (시각, ID) 쌍을 URL에 안전한 불투명 커서 문자열로 만듭니다.
+func encodeCursor(t time.Time, id int64) string {
+	raw := strconv.FormatInt(t.UnixMicro(), 10) + ":" + strconv.FormatInt(id, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeCursor 함수는 encodeCursor로 만든 커서를 (시각, ID) 쌍으로 되돌립니다.
+func decodeCursor(cursor string) (time.Time, int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+
+	micros, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return time.Time{}, 0, fmt.Errorf("잘못된 커서 형식: %q", raw)
+	}
+	us, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	gameID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	// DB의 TIMESTAMP 값은 UTC 기준 벽시계 시각으로 읽히므로 같은 기준으로 되돌립니다.
+	return time.UnixMicro(us).UTC(), gameID, nil
+}
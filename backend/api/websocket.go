@@ -0,0 +1,30 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/gorilla/websocket"
+)
+
+// wsUpgrader 모든 WebSocket API가 사용하는 연결 업그레이더입니다.
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin:     checkWebSocketOrigin,
+}
+
+// checkWebSocketOrigin 함수는 CORS와 같은 출처 목록(설정의 cors.allowed_origins) 또는 같은 호스트에서 온 연결만 허용합니다.
+// Origin 헤더가 없는 요청은 브라우저가 아니므로 허용합니다.
+func checkWebSocketOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if slices.Contains(appConfig.CORS.AllowedOrigins, origin) {
+		return true
+	}
+	u, err := url.Parse(origin)
+	return err == nil && u.Host == r.Host
+}
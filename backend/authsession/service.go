@@ -0,0 +1,294 @@
+// authsession 패키지는 기기별 로그인 세션과 한 번만 쓸 수 있는(회전하는) 리프레시 토큰을 관리합니다.
+// 이미 새 토큰으로 교체된 리프레시 토큰이 다시 쓰이면 탈취로 보고 그 세션(토큰 묶음) 전체를 폐기합니다.
+package authsession
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"games/backend/db/models"
+)
+
+// maxUserAgent 세션 목록에 보관하는 User-Agent 최대 길이입니다.
+const maxUserAgent = 512
+
+// 세션 폐기 사유입니다.
+const (
+	ReasonRevoked = "revoked" // 사용자가 기기 로그인을 해제함
+	ReasonReuse   = "reuse"   // 이미 사용된 리프레시 토큰이 다시 사용됨
+)
+
+var (
+	// ErrInvalidToken 리프레시 토큰이 없거나 폐기된 세션의 토큰일 때 반환됩니다.
+	ErrInvalidToken = errors.New("유효하지 않은 리프레시 토큰입니다")
+	// ErrExpired 리프레시 토큰의 유효 기간이 지났을 때 반환됩니다.
+	ErrExpired = errors.New("만료된 리프레시 토큰입니다")
+	// ErrReused 이미 사용된 리프레시 토큰이 다시 사용되었을 때 반환됩니다. 이때 세션은 폐기됩니다.
+	ErrReused = errors.New("이미 사용된 리프레시 토큰입니다. 보안을 위해 해당 기기의 로그인을 해제했습니다")
+	// ErrNotFound 해제할 로그인 세션이 없을 때 반환됩니다.
+	ErrNotFound = errors.New("로그인 세션을 찾을 수 없습니다")
+)
+
+// Client 세션 목록에서 기기를 구분하기 위한 요청 정보입니다.
+type Client struct {
+	UserAgent string
+	IP        string
+}
+
+// Token 새로 발급한 리프레시 토큰입니다. Value는 클라이언트에게 한 번만 전달하며 서버에는 해시만 저장합니다.
+type Token struct {
+	Value     string
+	SessionID string
+	UserID    int
+	ExpiresAt time.Time
+}
+
+// Service 로그인 세션 저장소(auth_sessions, refresh_tokens)에 접근하는 서비스입니다.
+type Service struct {
+	db         *sql.DB
+	refreshTTL time.Duration // 리프레시 토큰 하나의 유효 기간 (토큰을 갱신할 때마다 세션 만료 시각도 늘어남)
+}
+
+// NewService 함수는 로그인 세션 서비스를 생성합니다.
+func NewService(db *sql.DB, refreshTTL time.Duration) *Service {
+	return &Service{db: db, refreshTTL: refreshTTL}
+}
+
+// Create 함수는 로그인한 기기의 새 세션을 만들고 첫 리프레시 토큰을 발급합니다.
+func (s *Service) Create(userID int, client Client) (*Token, error) {
+	id, err := randomHex(16)
+	if err != nil {
+		return nil, fmt.Errorf("세션 ID 생성 실패: %v", err)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("트랜잭션 시작 실패: %v", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	_, err = tx.Exec(
+		`INSERT INTO auth_sessions (id, user_id, user_agent, ip, created_at, last_used_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $5, $6)`,
+		id, userID, truncate(client.UserAgent, maxUserAgent), client.IP, now, now.Add(s.refreshTTL),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("로그인 세션 저장 실패: %v", err)
+	}
+
+	token, err := s.issue(tx, id, userID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("로그인 세션 커밋 실패: %v", err)
+	}
+	return token, nil
+}
+
+// Rotate 함수는 리프레시 토큰을 사용 처리하고 같은 세션의 새 리프레시 토큰을 발급합니다.
+// 이미 사용된 토큰이면 세션을 폐기하고 ErrReused를 반환합니다.
+func (s *Service) Rotate(value string, client Client) (*Token, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("트랜잭션 시작 실패: %v", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	hash := hashToken(value)
+
+	// 같은 토큰으로 동시에 갱신하면 나중 요청은 잠금을 기다린 뒤 사용된 토큰으로 처리됨
+	var sessionID string
+	var userID int
+	var used, revoked, expired bool
+	err = tx.QueryRow(
+		`SELECT rt.session_id, s.user_id, rt.used_at IS NOT NULL, s.revoked_at IS NOT NULL, rt.expires_at <= $2::timestamp
+		FROM refresh_tokens rt
+		JOIN auth_sessions s ON s.id = rt.session_id
+		WHERE rt.token_hash = $1
+		FOR UPDATE`,
+		hash, now,
+	).Scan(&sessionID, &userID, &used, &revoked, &expired)
+	switch {
+	case err == sql.ErrNoRows:
+		return nil, ErrInvalidToken
+	case err != nil:
+		return nil, fmt.Errorf("리프레시 토큰 조회 실패: %v", err)
+	case revoked:
+		return nil, ErrInvalidToken
+	case used:
+		// 교체된 토큰을 누군가 가지고 있으므로 토큰 묶음 전체를 폐기
+		if err := revoke(tx, sessionID, ReasonReuse, now); err != nil {
+			return nil, err
+		}
+		if err := tx.Commit(); err != nil {
+			return nil, fmt.Errorf("로그인 세션 폐기 커밋 실패: %v", err)
+		}
+		return nil, ErrReused
+	case expired:
+		return nil, ErrExpired
+	}
+
+	if _, err := tx.Exec(`UPDATE refresh_tokens SET used_at = $1 WHERE token_hash = $2`, now, hash); err != nil {
+		return nil, fmt.Errorf("리프레시 토큰 사용 처리 실패: %v", err)
+	}
+
+	token, err := s.issue(tx, sessionID, userID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(
+		`UPDATE auth_sessions SET last_used_at = $1, expires_at = $2, user_agent = $3, ip = $4 WHERE id = $5`,
+		now, token.ExpiresAt, truncate(client.UserAgent, maxUserAgent), client.IP, sessionID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("로그인 세션 갱신 실패: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("리프레시 토큰 커밋 실패: %v", err)
+	}
+	return token, nil
+}
+
+// List 함수는 사용자의 폐기되지 않고 만료되지 않은 로그인 세션을 최근 사용 순으로 반환합니다.
+func (s *Service) List(userID int) ([]models.AuthSession, error) {
+	rows, err := s.db.Query(
+		`SELECT id, user_agent, ip, created_at, last_used_at, expires_at
+		FROM auth_sessions
+		WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > $2::timestamp
+		ORDER BY last_used_at DESC`,
+		userID, time.Now(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("로그인 세션 조회 실패: %v", err)
+	}
+	defer rows.Close()
+
+	sessions := []models.AuthSession{}
+	for rows.Next() {
+		var session models.AuthSession
+		if err := rows.Scan(&session.ID, &session.UserAgent, &session.IP, &session.CreatedAt, &session.LastUsedAt, &session.ExpiresAt); err != nil {
+			return nil, fmt.Errorf("로그인 세션 데이터 처리 실패: %v", err)
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("로그인 세션 조회 실패: %v", err)
+	}
+	return sessions, nil
+}
+
+// Revoke 함수는 사용자의 로그인 세션을 폐기해 그 기기의 리프레시 토큰을 더 이상 쓸 수 없게 합니다.
+func (s *Service) Revoke(userID int, sessionID string) error {
+	result, err := s.db.Exec(
+		`UPDATE auth_sessions SET revoked_at = $1, revoked_reason = $2
+		WHERE id = $3 AND user_id = $4 AND revoked_at IS NULL`,
+		time.Now(), ReasonRevoked, sessionID, userID,
+	)
+	if err != nil {
+		return fmt.Errorf("로그인 세션 폐기 실패: %v", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("처리 결과 확인 실패: %v", err)
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// RevokeToken 함수는 리프레시 토큰이 속한 로그인 세션을 폐기합니다. 액세스 토큰이 만료된 뒤의 로그아웃에 사용합니다.
+// 이미 교체된 토큰도 같은 세션의 토큰이므로 세션을 폐기합니다. 토큰이 없거나 이미 폐기된 세션이면 ErrInvalidToken을 반환합니다.
+func (s *Service) RevokeToken(value string) error {
+	result, err := s.db.Exec(
+		`UPDATE auth_sessions SET revoked_at = $1, revoked_reason = $2
+		WHERE id = (SELECT session_id FROM refresh_tokens WHERE token_hash = $3) AND revoked_at IS NULL`,
+		time.Now(), ReasonRevoked, hashToken(value),
+	)
+	if err != nil {
+		return fmt.Errorf("로그인 세션 폐기 실패: %v", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("처리 결과 확인 실패: %v", err)
+	}
+	if affected == 0 {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+// issue 함수는 세션의 새 리프레시 토큰을 만들어 해시를 저장합니다.
+func (s *Service) issue(tx *sql.Tx, sessionID string, userID int, now time.Time) (*Token, error) {
+	var buf [32]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return nil, fmt.Errorf("리프레시 토큰 생성 실패: %v", err)
+	}
+	token := &Token{
+		Value:     base64.RawURLEncoding.EncodeToString(buf[:]),
+		SessionID: sessionID,
+		UserID:    userID,
+		ExpiresAt: now.Add(s.refreshTTL),
+	}
+
+	_, err := tx.Exec(
+		`INSERT INTO refresh_tokens (token_hash, session_id, issued_at, expires_at) VALUES ($1, $2, $3, $4)`,
+		hashToken(token.Value), sessionID, now, token.ExpiresAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("리프레시 토큰 저장 실패: %v", err)
+	}
+	return token, nil
+}
+
+// revoke 함수는 트랜잭션 안에서 세션을 폐기합니다.
+func revoke(tx *sql.Tx, sessionID, reason string, now time.Time) error {
+	_, err := tx.Exec(
+		`UPDATE auth_sessions SET revoked_at = $1, revoked_reason = $2 WHERE id = $3 AND revoked_at IS NULL`,
+		now, reason, sessionID,
+	)
+	if err != nil {
+		return fmt.Errorf("로그인 세션 폐기 실패: %v", err)
+	}
+	return nil
+}
+
+// hashToken 함수는 저장과 조회에 쓰는 리프레시 토큰의 SHA-256 hex를 반환합니다.
+// 토큰은 256비트 난수이므로 느린 해시가 필요하지 않습니다.
+func hashToken(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+// randomHex 함수는 n바이트 난수의 hex 문자열을 반환합니다.
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// truncate 함수는 문자열을 최대 n바이트로 자릅니다. (UTF-8 문자 중간에서 자르지 않음)
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
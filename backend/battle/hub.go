@@ -0,0 +1,176 @@
+package battle
+
+import (
+	"context"
+	"time"
+)
+
+// 매치메이킹 설정입니다. 레이팅 차이 허용 범위는 기다린 시간에 따라 넓어집니다.
+const (
+	BaseWindow    = 50          // 처음 허용하는 레이팅 차이
+	WindowPerSec  = 10          // 기다린 1초마다 늘어나는 허용 범위
+	MaxWindow     = 1000        // 허용 범위 최댓값
+	matchInterval = time.Second // 대기열 재검사 주기
+)
+
+// Window 함수는 wait 동안 기다린 참가자가 허용하는 레이팅 차이를 반환합니다.
+func Window(wait time.Duration) int {
+	return min(BaseWindow+int(wait.Seconds())*WindowPerSec, MaxWindow)
+}
+
+// RecordFunc 끝난 대전 결과를 저장하고 사용자 ID별 새 레이팅을 반환하는 함수입니다.
+type RecordFunc func(result Result) (map[int]int, error)
+
+// ticket 매치메이킹 대기열의 참가자입니다.
+type ticket struct {
+	player   *Player
+	joinedAt time.Time
+}
+
+// Hub 대전 참가자를 레이팅이 비슷한 두 명씩 방으로 묶는 매치메이킹 허브입니다.
+// Run 고루틴만 대기열과 대전 중인 사용자 목록에 접근합니다.
+type Hub struct {
+	join    chan *Player
+	left    chan [2]int   // 끝난 방의 두 사용자 ID
+	stopped chan struct{} // Run이 끝나면 닫힘
+	record  RecordFunc
+}
+
+// NewHub 함수는 대전 허브를 생성합니다. record는 대전이 끝날 때마다 방 고루틴에서 호출되며 nil이면 결과를 저장하지 않습니다.
+// Run을 호출해야 참가자를 받습니다.
+func NewHub(record RecordFunc) *Hub {
+	return &Hub{join: make(chan *Player), left: make(chan [2]int), stopped: make(chan struct{}), record: record}
+}
+
+// Join 함수는 참가자를 대기열에 넣습니다. 허브가 종료되었으면 연결을 닫습니다.
+func (h *Hub) Join(p *Player) {
+	select {
+	case h.join <- p:
+	case <-h.stopped:
+		p.close()
+	}
+}
+
+// Run 함수는 ctx가 끝날 때까지 대기열에서 레이팅 차이가 허용 범위 안인 두 명을 묶어 방 고루틴을 시작합니다.
+func (h *Hub) Run(ctx context.Context) {
+	defer close(h.stopped)
+
+	var queue []*ticket
+	playing := make(map[int]bool) // 방에서 대전 중인 사용자
+	ticker := time.NewTicker(matchInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case p := <-h.join:
+			if playing[p.UserID] {
+				// 다른 연결로 대전 중인 사용자가 동시에 다른 대전을 시작하지 못하도록 거절
+				p.deliver(serverMessage{Type: MsgError, Message: "이미 대전 중입니다"})
+				p.close()
+				break
+			}
+			// 같은 사용자가 다시 들어오면 이전 연결을 닫고 새 연결로 기다림
+			queue = removeUser(queue, p.UserID)
+			queue = append(queue, &ticket{player: p, joinedAt: time.Now()})
+			p.deliver(serverMessage{Type: MsgWaiting, Rating: p.Rating})
+		case users := <-h.left:
+			delete(playing, users[0])
+			delete(playing, users[1])
+		case <-ticker.C:
+			// 기다린 시간만큼 넓어진 허용 범위로 다시 매칭
+		case <-ctx.Done():
+			for _, t := range queue {
+				t.player.close()
+			}
+			return
+		}
+
+		// 연결이 끊긴 참가자와 대전이 시작되어 상대가 공짜 승리를 얻지 않도록 먼저 제거
+		queue = h.match(ctx, removeDisconnected(queue), time.Now(), playing)
+	}
+}
+
+// match 함수는 오래 기다린 참가자부터 허용 범위 안에서 레이팅이 가장 가까운 상대를 찾아 방을 시작하고 남은 대기열을 반환합니다.
+// 대기열은 들어온 순서이므로, 두 참가자 중 먼저 들어온(더 오래 기다린) 쪽의 허용 범위를 사용합니다.
+// 방을 시작한 두 사용자는 playing에 표시합니다.
+func (h *Hub) match(ctx context.Context, queue []*ticket, now time.Time, playing map[int]bool) []*ticket {
+	matched := make([]bool, len(queue))
+	for i, a := range queue {
+		if matched[i] {
+			continue
+		}
+		window := Window(now.Sub(a.joinedAt))
+		best, bestDiff := -1, 0
+		for j := i + 1; j < len(queue); j++ {
+			if matched[j] {
+				continue
+			}
+			diff := abs(a.player.Rating - queue[j].player.Rating)
+			if diff <= window && (best < 0 || diff < bestDiff) {
+				best, bestDiff = j, diff
+			}
+		}
+		if best < 0 {
+			continue
+		}
+
+		matched[i], matched[best] = true, true
+		playing[a.player.UserID], playing[queue[best].player.UserID] = true, true
+		h.start(ctx, a.player, queue[best].player)
+	}
+
+	remaining := queue[:0]
+	for i, t := range queue {
+		if !matched[i] {
+			remaining = append(remaining, t)
+		}
+	}
+	return remaining
+}
+
+// start 함수는 두 참가자의 방 고루틴을 시작합니다. 대전이 끝나면 허브에 두 사용자가 방을 나갔음을 알립니다.
+func (h *Hub) start(ctx context.Context, a, b *Player) {
+	room := newRoom(a, b, h.record)
+	go func() {
+		result := room.run(ctx)
+		select {
+		case h.left <- result.Players:
+		case <-h.stopped:
+		}
+	}()
+}
+
+// removeUser 함수는 대기열에서 같은 사용자의 이전 연결을 닫고 제거합니다.
+func removeUser(queue []*ticket, userID int) []*ticket {
+	remaining := queue[:0]
+	for _, t := range queue {
+		if t.player.UserID == userID {
+			t.player.close()
+			continue
+		}
+		remaining = append(remaining, t)
+	}
+	return remaining
+}
+
+// removeDisconnected 함수는 대기열에서 연결이 끊긴 참가자를 제거합니다.
+func removeDisconnected(queue []*ticket) []*ticket {
+	remaining := queue[:0]
+	for _, t := range queue {
+		select {
+		case <-t.player.done:
+			t.player.close()
+		default:
+			remaining = append(remaining, t)
+		}
+	}
+	return remaining
+}
+
+// abs 함수는 정수의 절댓값을 반환합니다.
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
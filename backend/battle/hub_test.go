@@ -0,0 +1,70 @@
+package battle
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// next 함수는 참가자에게 보낸 다음 메시지를 기다립니다. 연결이 닫혔으면 ok가 false입니다.
+func next(t *testing.T, p *Player) (serverMessage, bool) {
+	t.Helper()
+	select {
+	case msg, ok := <-p.send:
+		return msg, ok
+	case <-time.After(3 * time.Second):
+		t.Fatal("메시지를 받지 못했습니다")
+		return serverMessage{}, false
+	}
+}
+
+func TestHubRejectsUserInRoom(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	hub := NewHub(nil)
+	go hub.Run(ctx)
+	defer func() {
+		cancel()
+		<-hub.stopped
+	}()
+
+	a, b := testPlayer(1), testPlayer(2)
+	hub.Join(a)
+	hub.Join(b)
+	for _, p := range []*Player{a, b} {
+		if msg, _ := next(t, p); msg.Type != MsgWaiting {
+			t.Fatalf("첫 메시지 = %q, 기대값 waiting", msg.Type)
+		}
+		if msg, _ := next(t, p); msg.Type != MsgMatched {
+			t.Fatalf("두 번째 메시지 = %q, 기대값 matched", msg.Type)
+		}
+	}
+
+	// 대전 중인 사용자가 다른 연결로 다시 대기열에 들어오면 거절
+	again := testPlayer(1)
+	hub.Join(again)
+	if msg, _ := next(t, again); msg.Type != MsgError {
+		t.Fatalf("대전 중 다시 들어온 연결의 메시지 = %q, 기대값 error", msg.Type)
+	}
+	if _, ok := next(t, again); ok {
+		t.Fatal("대전 중 다시 들어온 연결이 닫히지 않았습니다")
+	}
+
+	// 대전이 끝나면 다시 들어올 수 있음
+	close(b.done)
+	if msg, _ := next(t, a); msg.Type != MsgResult || msg.Reason != ReasonDisconnect {
+		t.Fatalf("대전 결과 = %+v, 기대값 disconnect", msg)
+	}
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		p := testPlayer(1)
+		hub.Join(p)
+		msg, _ := next(t, p)
+		if msg.Type == MsgWaiting {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("대전이 끝난 뒤에도 대기열에 들어갈 수 없습니다: %+v", msg)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,109 @@
+package battle
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"games/backend/wsconn"
+)
+
+// WebSocket 연결 설정입니다.
+const (
+	maxMessageSize = 8 * 1024 // 클라이언트 메시지 최대 크기 (input 메시지의 입력 64개 기준 여유 있게)
+	sendBuffer     = 64       // 보내기 대기열 크기 (가득 차면 느린 클라이언트의 메시지를 버림)
+	inboxBuffer    = 64       // 받은 메시지 대기열 크기 (가득 차면 연결을 끊음)
+)
+
+// Player WebSocket으로 연결된 대전 참가자입니다.
+// 읽기/쓰기 고루틴이 연결을 담당하고, 메시지 처리는 플레이어를 소유한 허브 또는 방 고루틴이 합니다.
+type Player struct {
+	UserID   int
+	Nickname string
+	Rating   int // 매치메이킹에 사용하는 대전 시작 시점의 레이팅
+
+	conn      *websocket.Conn
+	inbox     chan clientMessage // 받은 메시지
+	send      chan serverMessage // 보낼 메시지 (소유자만 닫음)
+	done      chan struct{}      // 연결이 끊기면 닫힘
+	closeOnce sync.Once
+}
+
+// NewPlayer 함수는 업그레이드된 WebSocket 연결로 참가자를 만들고 읽기/쓰기 고루틴을 시작합니다.
+func NewPlayer(conn *websocket.Conn, userID int, nickname string, rating int) *Player {
+	p := &Player{
+		UserID:   userID,
+		Nickname: nickname,
+		Rating:   rating,
+		conn:     conn,
+		inbox:    make(chan clientMessage, inboxBuffer),
+		send:     make(chan serverMessage, sendBuffer),
+		done:     make(chan struct{}),
+	}
+	go p.readPump()
+	go p.writePump()
+	return p
+}
+
+// deliver 함수는 메시지를 보내기 대기열에 넣습니다. 대기열이 가득 차면 메시지를 버리고 false를 반환합니다.
+func (p *Player) deliver(msg serverMessage) bool {
+	select {
+	case p.send <- msg:
+		return true
+	default:
+		return false
+	}
+}
+
+// close 함수는 남은 메시지를 보낸 뒤 연결을 닫습니다. 여러 번 호출해도 됩니다.
+func (p *Player) close() {
+	p.closeOnce.Do(func() { close(p.send) })
+}
+
+// readPump 함수는 클라이언트 메시지를 읽어 inbox로 보냅니다. 연결이 끊기면 done을 닫습니다.
+func (p *Player) readPump() {
+	defer close(p.done)
+
+	wsconn.KeepAlive(p.conn, maxMessageSize)
+
+	for {
+		var msg clientMessage
+		if err := p.conn.ReadJSON(&msg); err != nil {
+			return
+		}
+		// 입력을 하나라도 버리면 서버의 게임이 클라이언트와 달라지므로, 처리하는 쪽이 따라가지 못할 만큼
+		// 빠르게 보내는 클라이언트는 연결을 끊음 (읽기와 pong 처리가 멈추지 않도록 기다리지 않음)
+		select {
+		case p.inbox <- msg:
+		default:
+			return
+		}
+	}
+}
+
+// writePump 함수는 send 대기열의 메시지와 주기적인 ping을 보냅니다. send가 닫히면 연결을 닫습니다.
+func (p *Player) writePump() {
+	ticker := time.NewTicker(wsconn.PingPeriod)
+	defer func() {
+		ticker.Stop()
+		p.conn.Close()
+	}()
+
+	for {
+		select {
+		case msg, ok := <-p.send:
+			if !ok {
+				wsconn.WriteClose(p.conn)
+				return
+			}
+			if err := wsconn.WriteJSON(p.conn, msg); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := wsconn.WritePing(p.conn); err != nil {
+				return
+			}
+		}
+	}
+}
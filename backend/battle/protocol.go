@@ -0,0 +1,95 @@
+// battle 패키지는 WebSocket으로 두 플레이어를 한 방에 묶어 진행하는 1대1 테트리스 대전을 담당합니다.
+// 방마다 고루틴 하나가 대전 상태를 소유하고, 플레이어마다 받은 입력으로 tetris 엔진을 진행해
+// 줄 삭제, 가비지 전달, 게임 오버와 승자를 서버에서 판정합니다. 클라이언트가 보낸 점수나 줄 삭제는 믿지 않습니다.
+package battle
+
+import (
+	"time"
+
+	"games/backend/tetris"
+)
+
+// 클라이언트 -> 서버 메시지 종류입니다.
+const (
+	MsgInput = "input" // 진행한 스텝 수와 그 사이의 입력 (서버가 같은 엔진으로 진행)
+)
+
+// 서버 -> 클라이언트 메시지 종류입니다.
+const (
+	MsgWaiting       = "waiting"        // 레이팅이 비슷한 상대를 기다리는 중
+	MsgMatched       = "matched"        // 상대가 정해져 대전 시작 (같은 시드로 시작)
+	MsgOpponentBoard = "opponent_board" // 서버가 진행한 상대 보드와 점수
+	MsgGarbage       = "garbage"        // 상대가 보낸 가비지 줄 수 (ActionGarbage 입력으로 받아야 함)
+	MsgResult        = "result"         // 대전 결과
+	MsgError         = "error"          // 잘못된 메시지 (연결은 유지)
+)
+
+// 대전 종료 사유입니다.
+const (
+	ReasonTopOut     = "topout"     // 상대가 게임 오버
+	ReasonDisconnect = "disconnect" // 상대의 연결이 끊김
+	ReasonTimeout    = "timeout"    // 제한 시간이 지나 점수로 판정
+	ReasonInvalid    = "invalid"    // 상대가 규칙에 맞지 않는 입력을 보냄 (시간보다 빠른 진행, 너무 많은 입력, 받지 않은 가비지)
+	ReasonStalled    = "stalled"    // 상대가 너무 오래 입력을 보내지 않음
+	ReasonShutdown   = "shutdown"   // 서버 종료
+)
+
+// ActionGarbage 받은 가비지 묶음 중 가장 오래된 것 하나를 게임판에 추가하는 대전 전용 입력입니다.
+// 클라이언트는 garbage 메시지를 받으면 addGarbageLines를 호출하고 그 스텝에 이 입력을 기록합니다.
+const ActionGarbage = tetris.ActionHardDrop + 1
+
+// 입력 검증 설정입니다.
+const (
+	maxInputsPerMessage = 64                               // input 메시지 하나에 담을 수 있는 입력 수
+	maxActionsPerSecond = 40                               // 게임 시간 1초당 평균 입력 수 상한
+	actionBurst         = 100                              // 입력 수 상한에 더하는 여유
+	clockSlack          = 2 * time.Second                  // 클라이언트 진행 스텝과 서버 경과 시간을 비교할 때 허용하는 오차
+	garbageDeadline     = 3 * time.Second                  // 가비지를 보낸 뒤 받을 때까지 허용하는 게임 시간
+	maxLag              = 5 * time.Second                  // 클라이언트 진행이 서버 경과 시간보다 늦어도 되는 시간
+	stepsPerSecond      = 1000 / tetris.StepMs             // 게임 시간 1초의 스텝 수
+	stepDuration        = tetris.StepMs * time.Millisecond // 스텝 하나의 게임 시간
+)
+
+// clearGarbage 한 번에 지운 줄 수별로 상대에게 보내는 가비지 줄 수입니다.
+var clearGarbage = [5]int{0, 0, 1, 2, 4}
+
+// GarbageLines 함수는 한 번에 지운 줄 수와 콤보로 상대에게 보낼 가비지 줄 수를 계산합니다.
+// 싱글 0, 더블 1, 트리플 2, 테트리스 4줄이며, 콤보 2마다 1줄(최대 4줄)을 더합니다.
+func GarbageLines(cleared, combo int) int {
+	return clearGarbage[cleared] + min(combo/2, 4)
+}
+
+// clientMessage 클라이언트가 보내는 메시지입니다.
+// 클라이언트는 입력이 없어도 주기적으로(1초 이내) 진행한 스텝 수를 보내야 합니다.
+type clientMessage struct {
+	Type   string         `json:"type"`
+	Steps  int            `json:"steps"`            // input: 지금까지 진행한 스텝 수
+	Inputs []tetris.Input `json:"inputs,omitempty"` // input: 이전 메시지 이후의 [스텝, 입력 종류] (스텝 순서)
+}
+
+// Opponent 상대 플레이어 정보입니다.
+type Opponent struct {
+	UserID   int    `json:"user_id"`
+	Nickname string `json:"nickname"`
+	Rating   int    `json:"rating"`
+}
+
+// serverMessage 서버가 보내는 메시지입니다.
+type serverMessage struct {
+	Type     string                         `json:"type"`
+	Room     string                         `json:"room,omitempty"`          // matched
+	Seed     uint32                         `json:"seed,omitempty"`          // matched: 두 플레이어가 같은 블록 순서로 시작
+	Opponent *Opponent                      `json:"opponent,omitempty"`      // matched
+	Board    *[tetris.Rows][tetris.Cols]int `json:"board,omitempty"`         // opponent_board
+	Steps    int                            `json:"steps,omitempty"`         // opponent_board: 서버가 진행한 상대의 스텝 수
+	Score    int                            `json:"score,omitempty"`         // opponent_board
+	Lines    int                            `json:"lines,omitempty"`         // opponent_board
+	Level    int                            `json:"level,omitempty"`         // opponent_board
+	Garbage  int                            `json:"garbage,omitempty"`       // garbage
+	Deadline int                            `json:"deadline,omitempty"`      // garbage: 이 스텝 전에 ActionGarbage로 받아야 함
+	Winner   int                            `json:"winner,omitempty"`        // result: 이긴 사용자 ID (없으면 무승부)
+	Reason   string                         `json:"reason,omitempty"`        // result
+	Rating   int                            `json:"rating,omitempty"`        // waiting: 현재 레이팅, result: 대전 후 레이팅 (저장하지 않았으면 없음)
+	Change   *int                           `json:"rating_change,omitempty"` // result: 레이팅 변화량
+	Message  string                         `json:"message,omitempty"`       // error
+}
@@ -0,0 +1,211 @@
+package battle
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"log"
+	"time"
+)
+
+// MaxMatchDuration 대전 제한 시간입니다. 시간이 지나면 서버가 진행한 점수가 높은 플레이어가 이깁니다.
+const MaxMatchDuration = 10 * time.Minute
+
+// stallCheckInterval 입력을 보내지 않는 플레이어를 확인하는 주기입니다.
+const stallCheckInterval = time.Second
+
+// Result 끝난 대전의 결과입니다. WinnerID가 0이면 무승부(또는 서버 종료)입니다.
+type Result struct {
+	RoomID   string
+	Players  [2]int // 사용자 ID
+	WinnerID int
+	Reason   string
+	Duration time.Duration
+}
+
+// Rated 함수는 결과를 레이팅에 반영할 수 있는지 반환합니다.
+// 승패가 서버 엔진의 진행(게임 오버, 점수, 입력 검증)이나 서버가 감지한 연결 끊김으로 정해진 대전만 반영하며, 서버 종료로 끝난 대전은 제외합니다.
+func (r Result) Rated() bool {
+	switch r.Reason {
+	case ReasonTopOut, ReasonTimeout, ReasonInvalid, ReasonStalled, ReasonDisconnect:
+		return true
+	}
+	return false
+}
+
+// Room 두 플레이어의 대전 하나입니다. run 고루틴만 대전 상태에 접근합니다.
+type Room struct {
+	ID        string
+	Seed      uint32
+	players   [2]*contestant
+	startedAt time.Time
+	record    RecordFunc
+}
+
+// newRoom 함수는 두 플레이어로 새 방을 만들고 같은 블록 순서를 위한 시드를 정합니다.
+func newRoom(a, b *Player, record RecordFunc) *Room {
+	var buf [12]byte
+	rand.Read(buf[:])
+	seed := binary.BigEndian.Uint32(buf[8:])
+	return &Room{
+		ID:      hex.EncodeToString(buf[:8]),
+		Seed:    seed,
+		players: [2]*contestant{newContestant(a, seed), newContestant(b, seed)},
+		record:  record,
+	}
+}
+
+// run 함수는 대전이 끝날 때까지 두 플레이어의 메시지를 처리하고 결과를 반환합니다.
+// 끝나면 두 플레이어에게 결과를 보내고 연결을 닫습니다.
+func (r *Room) run(ctx context.Context) Result {
+	a, b := r.players[0], r.players[1]
+	r.startedAt = time.Now()
+
+	for i, p := range r.players {
+		opponent := r.players[1-i]
+		p.deliver(serverMessage{
+			Type:     MsgMatched,
+			Room:     r.ID,
+			Seed:     r.Seed,
+			Opponent: &Opponent{UserID: opponent.UserID, Nickname: opponent.Nickname, Rating: opponent.Rating},
+		})
+	}
+
+	timer := time.NewTimer(MaxMatchDuration)
+	defer timer.Stop()
+	ticker := time.NewTicker(stallCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		var winner *contestant
+		var reason string
+
+		select {
+		case msg := <-a.inbox:
+			winner, reason = r.handle(a, b, msg)
+		case msg := <-b.inbox:
+			winner, reason = r.handle(b, a, msg)
+		case <-a.done:
+			winner, reason = b, ReasonDisconnect
+		case <-b.done:
+			winner, reason = a, ReasonDisconnect
+		case <-ticker.C:
+			winner, reason = r.checkStalled()
+		case <-timer.C:
+			// 점수는 클라이언트가 보낸 값이 아닌 서버가 진행한 엔진의 값
+			reason = ReasonTimeout
+			if scoreA, scoreB := a.game.Result().Score, b.game.Result().Score; scoreA > scoreB {
+				winner = a
+			} else if scoreB > scoreA {
+				winner = b
+			}
+		case <-ctx.Done():
+			reason = ReasonShutdown
+		}
+
+		if reason != "" {
+			return r.finish(winner, reason)
+		}
+	}
+}
+
+// elapsedSteps 함수는 대전 시작 후 서버에서 흐른 시간을 스텝 수로 반환합니다.
+func (r *Room) elapsedSteps() int {
+	return int(time.Since(r.startedAt) / stepDuration)
+}
+
+// handle 함수는 from 플레이어의 메시지를 처리합니다. 대전이 끝나면 승자와 사유를 반환합니다.
+// 입력으로 from의 엔진을 진행한 뒤, 지운 줄만큼 가비지를 보내고 서버가 진행한 보드를 상대에게 전달합니다.
+func (r *Room) handle(from, to *contestant, msg clientMessage) (*contestant, string) {
+	if msg.Type != MsgInput {
+		from.deliver(serverMessage{Type: MsgError, Message: "알 수 없는 메시지 종류입니다"})
+		return nil, ""
+	}
+
+	garbage, err := from.advance(msg, r.elapsedSteps()+int(clockSlack/stepDuration))
+	if err != nil {
+		// 클라이언트와 서버의 게임이 달라졌으므로 대전을 이어갈 수 없음
+		log.Printf("대전 %s: 사용자 %d의 잘못된 입력: %v", r.ID, from.UserID, err)
+		from.deliver(serverMessage{Type: MsgError, Message: err.Error()})
+		return to, ReasonInvalid
+	}
+
+	for _, lines := range garbage {
+		deadline := r.elapsedSteps() + int(garbageDeadline/stepDuration)
+		to.pending = append(to.pending, garbageBatch{lines: lines, deadline: deadline})
+		from.sent += lines
+		if !to.deliver(serverMessage{Type: MsgGarbage, Garbage: lines, Deadline: deadline}) {
+			// 가비지를 받지 못하면 두 게임이 달라지므로 메시지가 밀린 클라이언트는 연결이 끊긴 것으로 처리
+			return from, ReasonDisconnect
+		}
+	}
+
+	board, result := from.game.Board(), from.game.Result()
+	to.deliver(serverMessage{
+		Type:  MsgOpponentBoard,
+		Board: &board,
+		Steps: result.Steps,
+		Score: result.Score,
+		Lines: result.Lines,
+		Level: result.Level,
+	})
+
+	if from.game.GameOver() {
+		return to, ReasonTopOut
+	}
+	return nil, ""
+}
+
+// checkStalled 함수는 진행이 서버 경과 시간보다 maxLag 넘게 늦은 플레이어를 찾아 승자와 사유를 반환합니다.
+// 입력을 보내지 않아 가비지를 피하는 것을 막으며, 둘 다 늦으면 무승부입니다.
+func (r *Room) checkStalled() (*contestant, string) {
+	limit := r.elapsedSteps() - int(maxLag/stepDuration)
+	a, b := r.players[0], r.players[1]
+	aLate, bLate := a.game.Steps() < limit, b.game.Steps() < limit
+	switch {
+	case aLate && bLate:
+		return nil, ReasonStalled
+	case aLate:
+		return b, ReasonStalled
+	case bLate:
+		return a, ReasonStalled
+	}
+	return nil, ""
+}
+
+// finish 함수는 결과를 저장(레이팅 갱신)한 뒤 두 플레이어에게 결과를 보내고 연결을 닫습니다.
+// 레이팅에 반영할 수 없는 결과(서버 종료)는 저장하지 않습니다.
+func (r *Room) finish(winner *contestant, reason string) Result {
+	result := Result{
+		RoomID:   r.ID,
+		Players:  [2]int{r.players[0].UserID, r.players[1].UserID},
+		Reason:   reason,
+		Duration: time.Since(r.startedAt),
+	}
+	if winner != nil {
+		result.WinnerID = winner.UserID
+	}
+
+	var ratings map[int]int
+	if r.record != nil && result.Rated() {
+		var err error
+		if ratings, err = r.record(result); err != nil {
+			log.Printf("대전 %s 결과 저장 실패: %v", r.ID, err)
+		}
+	}
+
+	for _, p := range r.players {
+		msg := serverMessage{Type: MsgResult, Winner: result.WinnerID, Reason: reason}
+		if rating, ok := ratings[p.UserID]; ok {
+			change := rating - p.Rating
+			msg.Rating, msg.Change = rating, &change
+		}
+		p.deliver(msg)
+		p.close()
+	}
+
+	log.Printf("대전 %s 종료: %d vs %d, 승자 %d (%s, %v)",
+		r.ID, result.Players[0], result.Players[1], result.WinnerID, reason, result.Duration.Round(time.Second))
+	return result
+}
@@ -0,0 +1,168 @@
+package battle
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"games/backend/tetris"
+)
+
+// testPlayer 함수는 WebSocket 연결 없이 보낸 메시지를 쌓아 두는 참가자를 만듭니다.
+func testPlayer(userID int) *Player {
+	return &Player{
+		UserID: userID,
+		inbox:  make(chan clientMessage, inboxBuffer),
+		send:   make(chan serverMessage, 4096),
+		done:   make(chan struct{}),
+	}
+}
+
+// testRoom 함수는 seed로 시작하고 elapsed만큼 지난 방을 만듭니다.
+func testRoom(seed uint32, elapsed time.Duration) *Room {
+	r := newRoom(testPlayer(1), testPlayer(2), nil)
+	r.Seed = seed
+	for _, c := range r.players {
+		c.game = tetris.NewGame(seed)
+	}
+	r.startedAt = time.Now().Add(-elapsed)
+	return r
+}
+
+// drain 함수는 참가자에게 보낸 메시지를 모두 꺼냅니다.
+func drain(p *Player) []serverMessage {
+	var msgs []serverMessage
+	for {
+		select {
+		case msg := <-p.send:
+			msgs = append(msgs, msg)
+		default:
+			return msgs
+		}
+	}
+}
+
+// TestRoomSimulatesBattle 함수는 tetris.js 봇 게임의 입력을 보내는 플레이어와 입력 없이 가비지만 받는 플레이어의 대전을
+// 서버 엔진으로 진행해, 가비지와 게임 오버가 서버에서 정해지는지 확인합니다.
+func TestRoomSimulatesBattle(t *testing.T) {
+	data, err := os.ReadFile("../tetris/testdata/parity.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fixture struct {
+		Games []struct {
+			Seed   uint32         `json:"seed"`
+			Steps  int            `json:"steps"`
+			Inputs []tetris.Input `json:"inputs"`
+		} `json:"games"`
+	}
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		t.Fatal(err)
+	}
+	game := fixture.Games[2] // 콤보로 가비지를 보내는 게임
+
+	r := testRoom(game.Seed, time.Hour)
+	a, b := r.players[0], r.players[1]
+
+	const chunk = 50 // input 메시지 하나가 진행하는 스텝 수
+	next, received := 0, 0
+	for steps := chunk; ; steps += chunk {
+		msg := clientMessage{Type: MsgInput, Steps: steps}
+		for next < len(game.Inputs) && game.Inputs[next].Step() <= steps {
+			msg.Inputs = append(msg.Inputs, game.Inputs[next])
+			next++
+		}
+		if winner, reason := r.handle(a, b, msg); reason != "" {
+			t.Fatalf("a의 입력 처리 중 대전 종료: 승자 %v, %s", winner.UserID, reason)
+		}
+
+		// b는 받은 가비지를 바로 받아들이고 같은 스텝만큼 진행
+		reply := clientMessage{Type: MsgInput, Steps: steps}
+		for _, m := range drain(b.Player) {
+			if m.Type == MsgGarbage {
+				received += m.Garbage
+				reply.Inputs = append(reply.Inputs, tetris.Input{b.game.Steps(), int(ActionGarbage)})
+			}
+		}
+		winner, reason := r.handle(b, a, reply)
+		if reason == "" {
+			continue
+		}
+
+		if reason != ReasonTopOut || winner != a {
+			t.Fatalf("대전 결과 = 승자 %d, %s / 기대값 a의 topout 승리", winner.UserID, reason)
+		}
+		break
+	}
+
+	if received == 0 || received != a.sent {
+		t.Fatalf("b가 받은 가비지 %d줄, a가 보낸 가비지 %d줄", received, a.sent)
+	}
+	if got := b.game.Result().GarbageSurvived; got < received {
+		t.Fatalf("b의 가비지 %d줄이 받은 %d줄보다 적습니다", got, received)
+	}
+}
+
+func TestRoomRejectsInvalidInput(t *testing.T) {
+	cases := map[string]struct {
+		elapsed time.Duration
+		pending []garbageBatch
+		msg     clientMessage
+	}{
+		"경과 시간보다 빠른 진행": {
+			elapsed: time.Second,
+			msg:     clientMessage{Type: MsgInput, Steps: 1000},
+		},
+		"너무 많은 입력": {
+			elapsed: time.Minute,
+			msg:     clientMessage{Type: MsgInput, Steps: 0, Inputs: repeat(tetris.Input{0, int(tetris.ActionRotate)}, actionBurst+1)},
+		},
+		"스텝 순서가 아닌 입력": {
+			elapsed: time.Minute,
+			msg:     clientMessage{Type: MsgInput, Steps: 10, Inputs: []tetris.Input{{5, 0}, {3, 0}}},
+		},
+		"알 수 없는 입력": {
+			elapsed: time.Minute,
+			msg:     clientMessage{Type: MsgInput, Steps: 10, Inputs: []tetris.Input{{5, int(ActionGarbage) + 1}}},
+		},
+		"보내지 않은 가비지": {
+			elapsed: time.Minute,
+			msg:     clientMessage{Type: MsgInput, Steps: 10, Inputs: []tetris.Input{{5, int(ActionGarbage)}}},
+		},
+		"받지 않은 가비지": {
+			elapsed: time.Minute,
+			pending: []garbageBatch{{lines: 2, deadline: 100}},
+			msg:     clientMessage{Type: MsgInput, Steps: 200},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := testRoom(1, tc.elapsed)
+			a, b := r.players[0], r.players[1]
+			a.pending = tc.pending
+			if winner, reason := r.handle(a, b, tc.msg); winner != b || reason != ReasonInvalid {
+				t.Fatalf("handle = %v, %q / 기대값 상대의 invalid 승리", winner, reason)
+			}
+		})
+	}
+}
+
+func TestRoomStalled(t *testing.T) {
+	r := testRoom(1, time.Minute)
+	a, b := r.players[0], r.players[1]
+	if winner, reason := r.handle(a, b, clientMessage{Type: MsgInput, Steps: r.elapsedSteps()}); reason != "" {
+		t.Fatalf("정상 입력으로 대전 종료: %v, %s", winner, reason)
+	}
+	if winner, reason := r.checkStalled(); winner != a || reason != ReasonStalled {
+		t.Fatalf("checkStalled = %v, %q / 기대값 a의 stalled 승리", winner, reason)
+	}
+}
+
+func repeat(input tetris.Input, n int) []tetris.Input {
+	inputs := make([]tetris.Input, n)
+	for i := range inputs {
+		inputs[i] = input
+	}
+	return inputs
+}
@@ -0,0 +1,99 @@
+package battle
+
+import (
+	"errors"
+	"fmt"
+
+	"games/backend/tetris"
+)
+
+// garbageBatch 상대에게 받았지만 아직 게임판에 추가하지 않은 가비지 묶음입니다.
+type garbageBatch struct {
+	lines    int
+	deadline int // 이 스텝까지 ActionGarbage로 받아야 함
+}
+
+// contestant 방 안에서 관리하는 참가자 상태입니다.
+// 클라이언트가 보낸 입력으로 방의 시드에서 시작한 엔진을 진행하며, 점수와 게임 오버는 이 엔진의 값만 사용합니다.
+type contestant struct {
+	*Player
+	game    *tetris.Game
+	pending []garbageBatch // 받아야 할 가비지 (보낸 순서)
+	actions int            // 지금까지 받은 입력 수
+	sent    int            // 상대에게 보낸 가비지 줄 수
+}
+
+// newContestant 함수는 방의 시드로 엔진을 시작한 참가자를 만듭니다.
+func newContestant(p *Player, seed uint32) *contestant {
+	return &contestant{Player: p, game: tetris.NewGame(seed)}
+}
+
+// advance 함수는 input 메시지의 입력을 스텝 순서대로 적용하며 엔진을 msg.Steps까지 진행합니다.
+// limit은 서버 경과 시간으로 도달할 수 있는 최대 스텝 수입니다.
+// 줄을 지울 때마다 상대에게 보낼 가비지 줄 수를 모아 반환하며, 규칙에 맞지 않는 입력이면 오류를 반환합니다.
+func (c *contestant) advance(msg clientMessage, limit int) ([]int, error) {
+	g := c.game
+	if msg.Steps < g.Steps() {
+		return nil, fmt.Errorf("진행한 스텝 수 %d가 이전 값 %d보다 작습니다", msg.Steps, g.Steps())
+	}
+	if msg.Steps > limit {
+		return nil, fmt.Errorf("진행한 스텝 수 %d가 대전 경과 시간보다 빠릅니다", msg.Steps)
+	}
+	if len(msg.Inputs) > maxInputsPerMessage {
+		return nil, fmt.Errorf("메시지 하나의 입력은 %d개까지입니다", maxInputsPerMessage)
+	}
+	if c.actions+len(msg.Inputs) > actionBurst+msg.Steps*maxActionsPerSecond/stepsPerSecond {
+		return nil, errors.New("입력이 너무 많습니다")
+	}
+	last := g.Steps()
+	for i, input := range msg.Inputs {
+		if input.Step() < last || input.Step() > msg.Steps {
+			return nil, fmt.Errorf("%d번째 입력의 스텝 %d가 잘못되었습니다", i, input.Step())
+		}
+		if input.Action() < tetris.ActionLeft || input.Action() > ActionGarbage {
+			return nil, fmt.Errorf("%d번째 입력의 종류 %d를 알 수 없습니다", i, input[1])
+		}
+		last = input.Step()
+	}
+	c.actions += len(msg.Inputs)
+
+	var garbage []int
+	// track 함수는 엔진을 한 번 진행하고 지운 줄이 있으면 보낼 가비지를 계산합니다.
+	track := func(run func()) {
+		lines := g.Result().Lines
+		run()
+		if cleared := g.Result().Lines - lines; cleared > 0 {
+			if n := GarbageLines(min(cleared, 4), g.Combo()); n > 0 {
+				garbage = append(garbage, n)
+			}
+		}
+	}
+	stepTo := func(step int) error {
+		for g.Steps() < step && !g.GameOver() {
+			if len(c.pending) > 0 && g.Steps() >= c.pending[0].deadline {
+				return fmt.Errorf("스텝 %d까지 받아야 할 가비지를 받지 않았습니다", c.pending[0].deadline)
+			}
+			track(g.Step)
+		}
+		return nil
+	}
+
+	for _, input := range msg.Inputs {
+		if err := stepTo(input.Step()); err != nil {
+			return garbage, err
+		}
+		if g.GameOver() {
+			break
+		}
+		if input.Action() == ActionGarbage {
+			if len(c.pending) == 0 {
+				return garbage, errors.New("받을 가비지가 없습니다")
+			}
+			g.AddGarbage(c.pending[0].lines)
+			c.pending = c.pending[1:]
+			continue
+		}
+		track(func() { g.Apply(input.Action()) })
+	}
+	return garbage, stepTo(msg.Steps)
+}
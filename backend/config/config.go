@@ -1,23 +1,118 @@
 // config 패키지는 애플리케이션 설정을 관리합니다.
+// 설정은 기본값, 설정 파일(YAML), 환경 변수, 명령행 플래그 순서로 읽으며 나중에 읽은 값이 우선합니다.
 package config
 
 import (
-	"os"
-)
+	"time"
+	_ "time/tzdata" // 시간대 데이터가 없는 컨테이너에서도 LoadLocation이 동작하도록 포함
 
-var (
-	// JWTSecret JWT 서명에 사용할 비밀키
-	JWTSecret []byte
+	"games/backend/jwtkeys"
 )
 
-// InitConfig 함수는 애플리케이션 설정을 초기화합니다.
-func InitConfig() {
-	// JWT 시크릿 설정 (환경변수에서 가져오거나, 기본값 사용)
-	secretKey := os.Getenv("JWT_SECRET")
-	if secretKey != "" {
-		JWTSecret = []byte(secretKey)
-	} else {
-		// 기본 비밀키 (실제 환경에서는 안전한 값으로 설정해야 함)
-		JWTSecret = []byte("your_jwt_secret")
+// Config 애플리케이션 설정입니다. Load로 읽어 검증한 뒤 라우터와 DB 계층에 넘깁니다.
+type Config struct {
+	Env         Environment       `yaml:"env"`
+	Server      ServerConfig      `yaml:"server"`
+	Database    DatabaseConfig    `yaml:"database"`
+	CORS        CORSConfig        `yaml:"cors"`
+	Auth        AuthConfig        `yaml:"auth"`
+	Leaderboard LeaderboardConfig `yaml:"leaderboard"`
+	Features    FeaturesConfig    `yaml:"features"`
+}
+
+// ServerConfig HTTP 서버 설정입니다.
+type ServerConfig struct {
+	Port      int    `yaml:"port"`
+	StaticDir string `yaml:"static_dir"` // 포함된 파일 대신 디스크에서 프론트엔드를 제공할 디렉토리 (개발용)
+}
+
+// DatabaseConfig PostgreSQL 연결과 마이그레이션 설정입니다.
+type DatabaseConfig struct {
+	URL             string        `yaml:"url"`
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+	Migrate         bool          `yaml:"migrate"`        // 서버 시작 전에 마이그레이션 적용 (false면 검증만 수행)
+	MigrationsDir   string        `yaml:"migrations_dir"` // 포함된 파일 대신 디스크에서 마이그레이션을 읽을 디렉토리 (개발용)
+}
+
+// CORSConfig CORS와 WebSocket 연결을 허용할 프론트엔드 출처 설정입니다.
+type CORSConfig struct {
+	AllowedOrigins []string `yaml:"allowed_origins"`
+}
+
+// AuthConfig 토큰 서명 키와 유효 기간 설정입니다.
+type AuthConfig struct {
+	JWTSecret         string        `yaml:"jwt_secret"`          // JWTKeysDir을 설정하지 않았을 때 HS256 서명에 사용
+	JWTKeysDir        string        `yaml:"jwt_keys_dir"`        // 액세스 토큰 서명용 RS256/EdDSA 비밀키와 keys.json이 있는 디렉토리
+	GameSessionSecret string        `yaml:"game_session_secret"` // 게임 세션 시드 서명 키 (기본값: JWTSecret)
+	AccessTokenTTL    time.Duration `yaml:"access_token_ttl"`
+	RefreshTokenTTL   time.Duration `yaml:"refresh_token_ttl"`
+}
+
+// LeaderboardConfig 리더보드 기간 경계와 순위 방식 설정입니다.
+type LeaderboardConfig struct {
+	Timezone string `yaml:"timezone"` // 일간/주간/월간 리더보드 기간 경계를 계산할 시간대
+	Ranking  string `yaml:"ranking"`  // 동점자 순위 방식 기본값 (competition: 1,2,2,4 / dense: 1,2,2,3)
+
+	Location *time.Location `yaml:"-"` // Timezone을 불러온 값 (Load에서 설정)
+}
+
+// FeaturesConfig 켜고 끌 수 있는 기능입니다. 끈 기능의 API는 404로 응답합니다.
+type FeaturesConfig struct {
+	Battle          bool `yaml:"battle"`           // 1대1 실시간 대전
+	Spectate        bool `yaml:"spectate"`         // 게임 중계/관전
+	LiveLeaderboard bool `yaml:"live_leaderboard"` // 실시간 리더보드 (SSE)
+}
+
+// Default 함수는 기본 설정을 반환합니다.
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{Port: 8080},
+		Database: DatabaseConfig{
+			MaxOpenConns:    25,
+			MaxIdleConns:    5,
+			ConnMaxLifetime: 5 * time.Minute,
+			Migrate:         true,
+		},
+		CORS: CORSConfig{
+			AllowedOrigins: []string{
+				// 로컬 개발 환경 포트들
+				"http://localhost:3000", "http://127.0.0.1:3000",
+				"http://localhost:8000", "http://127.0.0.1:8000",
+				"http://localhost:5500", "http://127.0.0.1:5500",
+				"http://localhost:8080", "http://127.0.0.1:8080",
+				"http://localhost:8081", "http://127.0.0.1:8081",
+				"http://localhost:4000", "http://127.0.0.1:4000",
+				"http://localhost", "http://127.0.0.1",
+
+				// 프로덕션 도메인
+				"https://kakaotech.my", "https://www.kakaotech.my",
+				"http://kakaotech.my", "http://www.kakaotech.my",
+			},
+		},
+		Auth: AuthConfig{
+			AccessTokenTTL:  15 * time.Minute,
+			RefreshTokenTTL: 30 * 24 * time.Hour,
+		},
+		Leaderboard: LeaderboardConfig{
+			// 사용자 대부분이 한국에 있으므로 KST 기본값
+			Timezone: "Asia/Seoul",
+			Ranking:  "competition",
+		},
+		Features: FeaturesConfig{
+			Battle:          true,
+			Spectate:        true,
+			LiveLeaderboard: true,
+		},
+	}
+}
+
+// SigningKeys 함수는 액세스 토큰 서명/검증 키 묶음을 만듭니다.
+// JWTKeysDir을 설정하면 비대칭 키로만 서명/검증하며, JWTSecret으로 서명된 HS256 토큰은 받지 않습니다.
+func (a AuthConfig) SigningKeys() (*jwtkeys.Ring, error) {
+	if a.JWTKeysDir == "" {
+		return jwtkeys.NewHMAC([]byte(a.JWTSecret)), nil
 	}
+	return jwtkeys.LoadDir(a.JWTKeysDir)
 }
@@ -0,0 +1,453 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Environment 실행 환경입니다. 운영 환경에서는 빠졌거나 약한 설정이 하나라도 있으면 시작하지 않습니다.
+type Environment string
+
+const (
+	// Development 로컬 개발 환경입니다. 설정 문제는 경고만 남기고 기본값을 사용합니다.
+	Development Environment = "development"
+	// Production 운영 환경입니다.
+	Production Environment = "production"
+)
+
+// 비밀키 최소 요구 사항입니다. (openssl rand -base64 48 등으로 생성한 값이면 충분)
+const (
+	minSecretBytes       = 32
+	minSecretEntropyBits = 96
+)
+
+// devJWTSecret 개발 환경에서 JWT_SECRET이 없을 때 쓰는 비밀키입니다. 운영 환경에서는 사용할 수 없습니다.
+const devJWTSecret = "your_jwt_secret"
+
+// setting 설정 파일 키, 환경 변수, 플래그로 지정할 수 있는 설정 하나입니다.
+type setting struct {
+	key     string // 설정 파일 키 (문제 설명에 사용)
+	env     string // 환경 변수 이름
+	flag    string // 플래그 이름 (빈 값이면 플래그로 지정할 수 없음)
+	usage   string
+	boolean bool // 값 없이 -flag만 써도 되는 플래그
+	set     func(c *Config, value string) error
+}
+
+// settings 환경 변수와 플래그로 덮어쓸 수 있는 설정 목록입니다.
+// 비밀키는 프로세스 목록에 노출되지 않도록 플래그로 받지 않습니다.
+var settings = []setting{
+	{key: "env", env: "APP_ENV", flag: "env", usage: "실행 환경 (development 또는 production)",
+		set: stringValue(func(c *Config) *string { return (*string)(&c.Env) })},
+	{key: "server.port", env: "PORT", flag: "port", usage: "HTTP 서버 포트",
+		set: intValue(func(c *Config) *int { return &c.Server.Port })},
+	{key: "server.static_dir", env: "STATIC_DIR", flag: "static-dir", usage: "포함된 파일 대신 디스크에서 프론트엔드를 제공할 디렉토리 (개발용)",
+		set: stringValue(func(c *Config) *string { return &c.Server.StaticDir })},
+	{key: "database.url", env: "DATABASE_URL", flag: "database-url", usage: "PostgreSQL 연결 문자열",
+		set: stringValue(func(c *Config) *string { return &c.Database.URL })},
+	{key: "database.max_open_conns", env: "DB_MAX_OPEN_CONNS",
+		set: intValue(func(c *Config) *int { return &c.Database.MaxOpenConns })},
+	{key: "database.max_idle_conns", env: "DB_MAX_IDLE_CONNS",
+		set: intValue(func(c *Config) *int { return &c.Database.MaxIdleConns })},
+	{key: "database.conn_max_lifetime", env: "DB_CONN_MAX_LIFETIME",
+		set: durationValue(func(c *Config) *time.Duration { return &c.Database.ConnMaxLifetime })},
+	{key: "database.migrate", env: "DB_MIGRATE", flag: "migrate", boolean: true, usage: "서버 시작 전에 적용되지 않은 마이그레이션을 적용합니다 (false면 검증만 수행)",
+		set: boolValue(func(c *Config) *bool { return &c.Database.Migrate })},
+	{key: "database.migrations_dir", env: "MIGRATIONS_DIR", flag: "migrations-dir", usage: "포함된 파일 대신 디스크에서 마이그레이션을 읽을 디렉토리 (개발용)",
+		set: stringValue(func(c *Config) *string { return &c.Database.MigrationsDir })},
+	{key: "cors.allowed_origins", env: "CORS_ALLOWED_ORIGINS",
+		set: listValue(func(c *Config) *[]string { return &c.CORS.AllowedOrigins })},
+	{key: "auth.jwt_secret", env: "JWT_SECRET",
+		set: stringValue(func(c *Config) *string { return &c.Auth.JWTSecret })},
+	{key: "auth.jwt_keys_dir", env: "JWT_KEYS_DIR",
+		set: stringValue(func(c *Config) *string { return &c.Auth.JWTKeysDir })},
+	{key: "auth.game_session_secret", env: "GAME_SESSION_SECRET",
+		set: stringValue(func(c *Config) *string { return &c.Auth.GameSessionSecret })},
+	{key: "auth.access_token_ttl", env: "ACCESS_TOKEN_TTL",
+		set: durationValue(func(c *Config) *time.Duration { return &c.Auth.AccessTokenTTL })},
+	{key: "auth.refresh_token_ttl", env: "REFRESH_TOKEN_TTL",
+		set: durationValue(func(c *Config) *time.Duration { return &c.Auth.RefreshTokenTTL })},
+	{key: "leaderboard.timezone", env: "LEADERBOARD_TZ",
+		set: stringValue(func(c *Config) *string { return &c.Leaderboard.Timezone })},
+	{key: "leaderboard.ranking", env: "LEADERBOARD_RANKING",
+		set: stringValue(func(c *Config) *string { return &c.Leaderboard.Ranking })},
+	{key: "features.battle", env: "FEATURE_BATTLE",
+		set: boolValue(func(c *Config) *bool { return &c.Features.Battle })},
+	{key: "features.spectate", env: "FEATURE_SPECTATE",
+		set: boolValue(func(c *Config) *bool { return &c.Features.Spectate })},
+	{key: "features.live_leaderboard", env: "FEATURE_LIVE_LEADERBOARD",
+		set: boolValue(func(c *Config) *bool { return &c.Features.LiveLeaderboard })},
+}
+
+// Flags 명령행 플래그로 받은 설정입니다. 명시한 플래그만 다른 설정보다 우선합니다.
+type Flags struct {
+	file   string
+	values []flagValue
+}
+
+// flagValue 명령행에서 지정한 설정 값 하나입니다.
+type flagValue struct {
+	setting *setting
+	value   string
+}
+
+// RegisterFlags 함수는 -config 플래그와 names로 지정한 설정 플래그를 fs에 등록합니다. names가 없으면 모든 설정 플래그를 등록합니다.
+// fs.Parse 이후에 Load에 넘깁니다.
+func RegisterFlags(fs *flag.FlagSet, names ...string) *Flags {
+	f := &Flags{}
+	fs.StringVar(&f.file, "config", "", "설정 파일(YAML) 경로 (기본값: CONFIG_FILE)")
+
+	for i := range settings {
+		s := &settings[i]
+		if s.flag == "" || (len(names) > 0 && !slices.Contains(names, s.flag)) {
+			continue
+		}
+		usage := fmt.Sprintf("%s [%s]", s.usage, s.env)
+		parse := func(value string) error {
+			// 잘못된 값은 플래그를 해석할 때 바로 알립니다.
+			if err := s.set(&Config{}, value); err != nil {
+				return err
+			}
+			f.values = append(f.values, flagValue{setting: s, value: value})
+			return nil
+		}
+		if s.boolean {
+			fs.BoolFunc(s.flag, usage, parse)
+		} else {
+			fs.Func(s.flag, usage, parse)
+		}
+	}
+	return f
+}
+
+// ValidationError 운영 환경에서 빠졌거나 잘못된 설정 목록입니다.
+type ValidationError struct {
+	Env      Environment
+	Problems []string
+}
+
+// Error 함수는 문제가 된 설정을 한 줄에 하나씩 나열합니다.
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "설정 오류 %d개 (APP_ENV=%s):", len(e.Problems), e.Env)
+	for _, problem := range e.Problems {
+		b.WriteString("\n  - ")
+		b.WriteString(problem)
+	}
+	return b.String()
+}
+
+// Load 함수는 기본값, 설정 파일, 환경 변수, 플래그 순서로 설정을 읽고 검증합니다. (나중에 읽은 값이 우선)
+// 설정 파일은 -config 플래그 또는 CONFIG_FILE 환경 변수로 지정하며, 없으면 읽지 않습니다. flags는 nil이어도 됩니다.
+// 운영 환경에서는 문제가 있으면 모든 문제를 담은 *ValidationError를 반환하고,
+// 개발 환경에서는 문제를 경고로 남긴 뒤 기본값으로 계속합니다.
+// 실행 환경을 지정하지 않았으면 개발 환경으로 실행하지만, 비밀키에 문제가 있으면 운영 환경처럼 시작하지 않습니다.
+// keys를 지정하면 그 설정(예: database는 database.* 전체)과 env만 검증합니다. migrate처럼 일부 설정만 쓰는 명령에서 사용합니다.
+func Load(flags *Flags, keys ...string) (*Config, error) {
+	cfg := Default()
+
+	file := os.Getenv("CONFIG_FILE")
+	if flags != nil && flags.file != "" {
+		file = flags.file
+	}
+	if file != "" {
+		if err := cfg.readFile(file); err != nil {
+			return nil, err
+		}
+	}
+
+	var problems []string
+	for i := range settings {
+		s := &settings[i]
+		if value := os.Getenv(s.env); value != "" {
+			if err := s.set(cfg, value); err != nil && inScope(keys, s.key) {
+				problems = append(problems, fmt.Sprintf("%s: %v", s.describe(), err))
+			}
+		}
+	}
+	if flags != nil {
+		for _, v := range flags.values {
+			v.setting.set(cfg, v.value) // RegisterFlags에서 검사한 값
+		}
+	}
+
+	checked, failClosed := cfg.validate(keys)
+	problems = append(problems, checked...)
+	if len(problems) > 0 {
+		if cfg.Env == Production {
+			return nil, &ValidationError{Env: cfg.Env, Problems: problems}
+		}
+		if failClosed {
+			return nil, &ValidationError{Problems: problems} // APP_ENV가 비어 있음을 표시
+		}
+		for _, problem := range problems {
+			log.Printf("설정 경고 (개발 환경이라 기본값으로 계속합니다): %s", problem)
+		}
+	}
+	return cfg, nil
+}
+
+// readFile 함수는 YAML 설정 파일의 값으로 설정을 덮어씁니다. 파일에 없는 항목은 그대로 두며, 알 수 없는 키는 오류입니다.
+func (c *Config) readFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("설정 파일 읽기 실패: %v", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("설정 파일 %s 형식 오류: %v", path, err)
+	}
+	return nil
+}
+
+// validate 함수는 설정을 검사해 문제 목록을 반환합니다. 잘못된 값은 기본값으로 되돌려 개발 환경에서 계속 실행할 수 있게 합니다.
+// 실행 환경을 지정하지 않았는데 비밀키에 문제가 있으면 failClosed가 true입니다. (개발용 비밀키는 APP_ENV=development를 지정해야 사용)
+// scope가 있으면 그 범위 밖 설정의 문제는 보고하지 않습니다. (값은 똑같이 기본값으로 되돌림)
+func (c *Config) validate(scope []string) (problems []string, failClosed bool) {
+	problem := func(key, format string, args ...interface{}) {
+		if inScope(scope, key) {
+			problems = append(problems, describe(key)+": "+fmt.Sprintf(format, args...))
+		}
+	}
+	def := Default()
+
+	// 실행 환경 (지정하지 않으면 GIN_MODE=release일 때 운영 환경, 알 수 없는 값은 안전하게 운영 환경으로 처리)
+	explicitEnv := c.Env != ""
+	switch c.Env {
+	case Production, "prod":
+		c.Env = Production
+	case Development, "dev":
+		c.Env = Development
+	case "":
+		c.Env = Development
+		if os.Getenv("GIN_MODE") == "release" {
+			c.Env = Production
+		}
+	default:
+		problem("env", "값 %q를 알 수 없습니다 (development 또는 production)", c.Env)
+		c.Env = Production
+	}
+
+	if c.Server.Port < 1 || c.Server.Port > 65535 {
+		problem("server.port", "1~65535 사이여야 합니다 (현재 %d)", c.Server.Port)
+		c.Server.Port = def.Server.Port
+	}
+
+	// DB 연결 (개발 환경에서는 DB가 필요한 명령에서만 연결 시 확인)
+	if c.Database.URL == "" && c.Env == Production {
+		problem("database.url", "설정되지 않았습니다")
+	}
+	if c.Database.MaxOpenConns < 1 {
+		problem("database.max_open_conns", "1 이상이어야 합니다 (현재 %d)", c.Database.MaxOpenConns)
+		c.Database.MaxOpenConns = def.Database.MaxOpenConns
+	}
+	if c.Database.MaxIdleConns < 0 || c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		problem("database.max_idle_conns", "0 이상 %d(max_open_conns) 이하여야 합니다 (현재 %d)", c.Database.MaxOpenConns, c.Database.MaxIdleConns)
+		c.Database.MaxIdleConns = min(def.Database.MaxIdleConns, c.Database.MaxOpenConns)
+	}
+	if c.Database.ConnMaxLifetime < 0 {
+		problem("database.conn_max_lifetime", "0 이상이어야 합니다 (현재 %s)", c.Database.ConnMaxLifetime)
+		c.Database.ConnMaxLifetime = def.Database.ConnMaxLifetime
+	}
+
+	if len(c.CORS.AllowedOrigins) == 0 {
+		problem("cors.allowed_origins", "출처를 하나 이상 지정해야 합니다")
+		c.CORS.AllowedOrigins = def.CORS.AllowedOrigins
+	}
+	for _, origin := range c.CORS.AllowedOrigins {
+		if !strings.HasPrefix(origin, "http://") && !strings.HasPrefix(origin, "https://") || strings.HasSuffix(origin, "/") {
+			problem("cors.allowed_origins", "출처 %q는 http(s)://host[:port] 형식이어야 합니다", origin)
+		}
+	}
+
+	// 액세스 토큰 서명 키 (키 디렉토리를 쓰면 JWT 시크릿은 게임 세션 서명에만 쓰임)
+	before := len(problems)
+	if c.Auth.JWTKeysDir == "" && inScope(scope, "auth.jwt_secret") {
+		problems = appendProblem(problems, checkSecret(describe("auth.jwt_secret"), c.Auth.JWTSecret))
+	}
+	// 게임 세션 서명 키 (따로 지정하지 않으면 JWT 시크릿 사용)
+	switch {
+	case !inScope(scope, "auth.game_session_secret"):
+	case c.Auth.GameSessionSecret != "":
+		problems = appendProblem(problems, checkSecret(describe("auth.game_session_secret"), c.Auth.GameSessionSecret))
+	case c.Auth.JWTKeysDir != "":
+		problems = appendProblem(problems, checkSecret(describe("auth.jwt_secret")+", game_session_secret 기본값", c.Auth.JWTSecret))
+	}
+	if len(problems) > before && !explicitEnv && c.Env == Development {
+		// 운영 서버에서 APP_ENV를 빠뜨렸을 때 예시 비밀키로 토큰을 서명하지 않도록 시작하지 않음
+		problem("env", "지정되지 않아 비밀키 문제를 무시할 수 없습니다 (개발용 기본 비밀키를 쓰려면 development를 지정)")
+		failClosed = true
+	}
+	if c.Auth.JWTSecret == "" && c.Env == Development && explicitEnv {
+		c.Auth.JWTSecret = devJWTSecret
+	}
+	if c.Auth.GameSessionSecret == "" {
+		c.Auth.GameSessionSecret = c.Auth.JWTSecret
+	}
+
+	if c.Auth.AccessTokenTTL <= 0 {
+		problem("auth.access_token_ttl", "0보다 커야 합니다 (현재 %s)", c.Auth.AccessTokenTTL)
+		c.Auth.AccessTokenTTL = def.Auth.AccessTokenTTL
+	}
+	if c.Auth.RefreshTokenTTL <= c.Auth.AccessTokenTTL {
+		problem("auth.refresh_token_ttl", "액세스 토큰 유효 기간(%s)보다 길어야 합니다 (현재 %s)", c.Auth.AccessTokenTTL, c.Auth.RefreshTokenTTL)
+		c.Auth.RefreshTokenTTL = max(def.Auth.RefreshTokenTTL, 2*c.Auth.AccessTokenTTL)
+	}
+
+	// 리더보드 시간대와 순위 방식 (요청의 ranking 파라미터가 없을 때 사용)
+	loc, err := time.LoadLocation(c.Leaderboard.Timezone)
+	if err != nil {
+		problem("leaderboard.timezone", "시간대 %q를 불러올 수 없습니다 (%v)", c.Leaderboard.Timezone, err)
+		loc = time.FixedZone("KST", 9*60*60)
+	}
+	c.Leaderboard.Location = loc
+
+	switch c.Leaderboard.Ranking {
+	case "competition", "dense":
+	default:
+		problem("leaderboard.ranking", "값 %q를 알 수 없습니다 (competition 또는 dense)", c.Leaderboard.Ranking)
+		c.Leaderboard.Ranking = def.Leaderboard.Ranking
+	}
+	return problems, failClosed
+}
+
+// checkSecret 함수는 비밀키가 비어 있거나, 예시 값이거나, 짧거나, 추측하기 쉬우면 문제 설명을 반환합니다.
+func checkSecret(name, secret string) string {
+	switch {
+	case secret == "":
+		return name + ": 설정되지 않았습니다"
+	case strings.Contains(strings.ToLower(secret), devJWTSecret):
+		return fmt.Sprintf("%s: 예시 값(%s)은 사용할 수 없습니다", name, devJWTSecret)
+	case len(secret) < minSecretBytes:
+		return fmt.Sprintf("%s: %d바이트 이상이어야 합니다 (현재 %d바이트)", name, minSecretBytes, len(secret))
+	}
+	if bits := entropyBits(secret); bits < minSecretEntropyBits {
+		return fmt.Sprintf("%s: 같은 문자가 너무 많이 반복됩니다 (추정 엔트로피 %.0f비트, %d비트 이상 필요)", name, bits, minSecretEntropyBits)
+	}
+	return ""
+}
+
+// entropyBits 함수는 문자 빈도로 계산한 섀넌 엔트로피에 길이를 곱해 비밀키의 엔트로피를 추정합니다.
+// 무작위로 만든 값인지 판별할 수는 없지만 "aaaa..."나 짧은 패턴 반복 같은 값은 걸러냅니다.
+func entropyBits(secret string) float64 {
+	counts := make(map[byte]int)
+	for i := 0; i < len(secret); i++ {
+		counts[secret[i]]++
+	}
+
+	n := float64(len(secret))
+	perByte := 0.0
+	for _, count := range counts {
+		p := float64(count) / n
+		perByte -= p * math.Log2(p)
+	}
+	return perByte * n
+}
+
+// inScope 함수는 설정 key가 검증 범위 scope에 드는지 반환합니다. scope가 없으면 모든 설정, env는 항상 범위에 듭니다.
+func inScope(scope []string, key string) bool {
+	if len(scope) == 0 || key == "env" {
+		return true
+	}
+	for _, prefix := range scope {
+		if key == prefix || strings.HasPrefix(key, prefix+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// appendProblem 함수는 문제 설명이 있으면 목록에 추가합니다.
+func appendProblem(problems []string, problem string) []string {
+	if problem == "" {
+		return problems
+	}
+	return append(problems, problem)
+}
+
+// describe 함수는 문제 설명에 쓸 설정 이름을 반환합니다. (예: auth.jwt_secret (JWT_SECRET))
+func describe(key string) string {
+	for _, s := range settings {
+		if s.key == key {
+			return s.describe()
+		}
+	}
+	return key
+}
+
+// describe 함수는 설정 파일 키와 환경 변수 이름을 함께 반환합니다.
+func (s *setting) describe() string {
+	return fmt.Sprintf("%s (%s)", s.key, s.env)
+}
+
+// stringValue 함수는 문자열 설정의 값 지정 함수를 만듭니다.
+func stringValue(field func(*Config) *string) func(*Config, string) error {
+	return func(c *Config, value string) error {
+		*field(c) = value
+		return nil
+	}
+}
+
+// intValue 함수는 정수 설정의 값 지정 함수를 만듭니다.
+func intValue(field func(*Config) *int) func(*Config, string) error {
+	return func(c *Config, value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("정수가 아닙니다: %q", value)
+		}
+		*field(c) = n
+		return nil
+	}
+}
+
+// boolValue 함수는 참/거짓 설정의 값 지정 함수를 만듭니다.
+func boolValue(field func(*Config) *bool) func(*Config, string) error {
+	return func(c *Config, value string) error {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("true 또는 false가 아닙니다: %q", value)
+		}
+		*field(c) = b
+		return nil
+	}
+}
+
+// durationValue 함수는 기간 설정(예: 15m, 720h)의 값 지정 함수를 만듭니다.
+func durationValue(field func(*Config) *time.Duration) func(*Config, string) error {
+	return func(c *Config, value string) error {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("기간 형식(예: 15m, 720h)이 아닙니다: %q", value)
+		}
+		*field(c) = d
+		return nil
+	}
+}
+
+// listValue 함수는 쉼표로 구분한 목록 설정의 값 지정 함수를 만듭니다.
+func listValue(field func(*Config) *[]string) func(*Config, string) error {
+	return func(c *Config, value string) error {
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		*field(c) = items
+		return nil
+	}
+}
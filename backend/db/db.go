@@ -3,24 +3,40 @@ package db
 
 import (
 	"database/sql"
-	"fmt"
+	"io/fs"
 	"log"
 	"os"
-	"path/filepath"
 	"strings"
 	"time"
 
 	_ "github.com/lib/pq" // PostgreSQL 드라이버
+
+	"games/backend/config"
+	"games/backend/db/migrations"
 )
 
 // DB 전역 변수로 선언
 var DB *sql.DB
 
-// InitDB 함수는 PostgreSQL 데이터베이스에 연결을 설정합니다.
-func InitDB() {
-	dbURL := os.Getenv("DATABASE_URL")
+// migrationSource 마이그레이션 파일을 읽어 올 파일 시스템 (기본값: 바이너리에 포함된 파일)
+var migrationSource fs.FS = migrations.FS
+
+// SetMigrationsDir 함수는 포함된 파일 대신 디스크의 dir 디렉토리에서 마이그레이션을 읽도록 설정합니다. (개발용)
+func SetMigrationsDir(dir string) {
+	log.Printf("마이그레이션 파일을 디스크에서 읽습니다: %s", dir)
+	migrationSource = os.DirFS(dir)
+}
+
+// MigrationSource 함수는 현재 사용 중인 마이그레이션 파일 시스템을 반환합니다.
+func MigrationSource() fs.FS {
+	return migrationSource
+}
+
+// InitDB 함수는 설정의 연결 문자열과 연결 풀 크기로 PostgreSQL 데이터베이스에 연결합니다.
+func InitDB(cfg config.DatabaseConfig) {
+	dbURL := cfg.URL
 	if dbURL == "" {
-		log.Fatal("DATABASE_URL 환경변수가 설정되지 않았습니다.")
+		log.Fatal("데이터베이스 연결 문자열(DATABASE_URL)이 설정되지 않았습니다.")
 	}
 
 	// DB 연결 시작 시간 기록
@@ -43,9 +59,9 @@ func InitDB() {
 	}
 
 	// 연결 설정
-	DB.SetMaxOpenConns(25)                 // 최대 연결 수
-	DB.SetMaxIdleConns(5)                  // 유휴 연결 수
-	DB.SetConnMaxLifetime(5 * time.Minute) // 연결 최대 수명
+	DB.SetMaxOpenConns(cfg.MaxOpenConns)       // 최대 연결 수
+	DB.SetMaxIdleConns(cfg.MaxIdleConns)       // 유휴 연결 수
+	DB.SetConnMaxLifetime(cfg.ConnMaxLifetime) // 연결 최대 수명
 
 	// 실제 연결 테스트
 	if err = DB.Ping(); err != nil {
@@ -70,11 +86,6 @@ func InitDB() {
 	log.Printf("연결 통계: 열린 연결 %d개, 사용 중인 연결 %d개, 유휴 연결 %d개",
 		stats.OpenConnections, stats.InUse, stats.Idle)
 
-	// 마이그레이션 실행: 테이블이 없으면 생성합니다.
-	if err := runMigrations(); err != nil {
-		log.Fatal("마이그레이션 실패:", err)
-	}
-
 	// 테이블 수 확인
 	var tableCount int
 	err = DB.QueryRow(`
@@ -97,54 +108,33 @@ func InitDB() {
 	}
 }
 
-// runMigrations 함수는 migrations 폴더에 있는 SQL 파일을 읽어 실행합니다.
-func runMigrations() error {
+// RunMigrations 함수는 마이그레이션 중 아직 적용되지 않은 것을 적용합니다.
+func RunMigrations() error {
 	log.Println("데이터베이스 마이그레이션 시작...")
-	// 마이그레이션 디렉토리 경로
-	migrationsDir := "db/migrations"
-
-	// 사용자 테이블 마이그레이션
-	usersTableSQL, err := os.ReadFile(filepath.Join(migrationsDir, "create_users_table.sql"))
-	if err != nil {
-		return fmt.Errorf("사용자 테이블 마이그레이션 파일 읽기 실패: %v", err)
-	}
-
-	log.Println("사용자 테이블 마이그레이션 실행 중...")
-	// SQL 실행
-	_, err = DB.Exec(string(usersTableSQL))
-	if err != nil {
-		return fmt.Errorf("사용자 테이블 마이그레이션 실행 실패: %v", err)
-	}
-	log.Println("사용자 테이블 마이그레이션 완료")
+	_, err := NewMigrator(DB, migrationSource).Up()
+	return err
+}
 
-	// 게임 기록 테이블 마이그레이션
-	gameRecordsSQL, err := os.ReadFile(filepath.Join(migrationsDir, "create_game_records_table.sql"))
-	if err != nil {
-		return fmt.Errorf("게임 기록 테이블 마이그레이션 파일 읽기 실패: %v", err)
+// VerifyMigrations 함수는 마이그레이션을 적용하지 않고 적용된 파일이 수정되지 않았는지만 확인합니다.
+// 적용되지 않은 버전이 남아 있으면 경고만 기록합니다.
+func VerifyMigrations() error {
+	migrator := NewMigrator(DB, migrationSource)
+	if err := migrator.Verify(); err != nil {
+		return err
 	}
 
-	log.Println("게임 기록 테이블 마이그레이션 실행 중...")
-	// SQL 실행
-	_, err = DB.Exec(string(gameRecordsSQL))
+	statuses, err := migrator.Status()
 	if err != nil {
-		return fmt.Errorf("게임 기록 테이블 마이그레이션 실행 실패: %v", err)
+		return err
 	}
-	log.Println("게임 기록 테이블 마이그레이션 완료")
-
-	// 테트리스 점수 테이블 마이그레이션 추가
-	tetrisScoresSQL, err := os.ReadFile(filepath.Join(migrationsDir, "create_tetris_scores_table.sql"))
-	if err != nil {
-		return fmt.Errorf("테트리스 점수 테이블 마이그레이션 파일 읽기 실패: %v", err)
+	pending := 0
+	for _, status := range statuses {
+		if !status.Applied {
+			pending++
+		}
 	}
-
-	log.Println("테트리스 점수 테이블 마이그레이션 실행 중...")
-	// SQL 실행
-	_, err = DB.Exec(string(tetrisScoresSQL))
-	if err != nil {
-		return fmt.Errorf("테트리스 점수 테이블 마이그레이션 실행 실패: %v", err)
+	if pending > 0 {
+		log.Printf("경고: 적용되지 않은 마이그레이션이 %d개 있습니다. `migrate up`을 실행하세요.", pending)
 	}
-	log.Println("테트리스 점수 테이블 마이그레이션 완료")
-
-	log.Println("마이그레이션이 성공적으로 완료되었습니다.")
 	return nil
 }
@@ -0,0 +1,483 @@
+package db
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MigrationsDir 소스 트리에서 마이그레이션 SQL 파일이 위치한 디렉토리입니다. (migrate create 기본 경로)
+const MigrationsDir = "db/migrations"
+
+// migrationLockID 동시에 여러 프로세스가 마이그레이션을 실행하지 않도록 잡는 advisory lock 키입니다.
+const migrationLockID = 7_243_001
+
+// migrationFilePattern 마이그레이션 파일 이름 규칙입니다. 예: 0001_create_users_table.up.sql
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+var (
+	// ErrChecksumMismatch 이미 적용된 마이그레이션 파일이 수정되었을 때 반환됩니다.
+	ErrChecksumMismatch = errors.New("적용된 마이그레이션 파일이 수정되었습니다")
+	// ErrMissingMigration 적용 이력은 있지만 파일이 존재하지 않을 때 반환됩니다.
+	ErrMissingMigration = errors.New("적용된 마이그레이션 파일을 찾을 수 없습니다")
+)
+
+// Migration 하나의 버전에 해당하는 up/down SQL 쌍입니다.
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string // up SQL의 SHA-256 해시
+}
+
+// AppliedMigration schema_migrations 테이블에 기록된 적용 이력입니다.
+type AppliedMigration struct {
+	Version   int64
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// Migrator 버전 관리되는 마이그레이션을 적용/검증합니다.
+type Migrator struct {
+	db     *sql.DB
+	source fs.FS
+}
+
+// NewMigrator 함수는 source 파일 시스템에서 마이그레이션을 읽는 Migrator를 생성합니다.
+func NewMigrator(db *sql.DB, source fs.FS) *Migrator {
+	return &Migrator{db: db, source: source}
+}
+
+// LoadMigrations 함수는 source 최상위의 마이그레이션 파일을 읽어 버전 순으로 정렬해 반환합니다.
+func LoadMigrations(source fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(source, ".")
+	if err != nil {
+		return nil, fmt.Errorf("마이그레이션 디렉토리 읽기 실패: %v", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("잘못된 마이그레이션 버전 %q: %v", entry.Name(), err)
+		}
+
+		content, err := fs.ReadFile(source, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("마이그레이션 파일 %s 읽기 실패: %v", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("마이그레이션 버전 %d가 중복되었습니다: %s, %s", version, m.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			m.Up = string(content)
+			m.Checksum = checksum(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("마이그레이션 %04d_%s에 up 파일이 없습니다", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// checksum 함수는 마이그레이션 내용의 SHA-256 해시를 16진수 문자열로 반환합니다.
+func checksum(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// Up 함수는 아직 적용되지 않은 마이그레이션을 버전 순서대로 적용하고 적용한 개수를 반환합니다.
+// 각 마이그레이션은 하나의 트랜잭션 안에서 실행되며, 이미 적용된 파일이 수정되었으면 아무것도 적용하지 않습니다.
+func (m *Migrator) Up() (int, error) {
+	migrations, err := LoadMigrations(m.source)
+	if err != nil {
+		return 0, err
+	}
+
+	ctx := context.Background()
+	conn, err := m.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer m.unlock(ctx, conn)
+
+	applied, err := appliedMigrations(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+	if err := verify(migrations, applied); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, migration := range migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+
+		log.Printf("마이그레이션 %04d_%s 적용 중...", migration.Version, migration.Name)
+		if err := applyMigration(ctx, conn, migration); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	if count == 0 {
+		log.Println("적용할 마이그레이션이 없습니다. 스키마가 최신 상태입니다.")
+	} else {
+		log.Printf("마이그레이션 %d개를 적용했습니다.", count)
+	}
+	return count, nil
+}
+
+// Verify 함수는 적용된 마이그레이션 파일이 모두 존재하고 수정되지 않았는지 확인합니다.
+func (m *Migrator) Verify() error {
+	migrations, err := LoadMigrations(m.source)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return fmt.Errorf("DB 연결 획득 실패: %v", err)
+	}
+	defer conn.Close()
+
+	if err := ensureMigrationsTable(ctx, conn); err != nil {
+		return err
+	}
+	applied, err := appliedMigrations(ctx, conn)
+	if err != nil {
+		return err
+	}
+	return verify(migrations, applied)
+}
+
+// lock 함수는 전용 연결을 잡고 마이그레이션 advisory lock을 획득합니다.
+func (m *Migrator) lock(ctx context.Context) (*sql.Conn, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("DB 연결 획득 실패: %v", err)
+	}
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockID); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("마이그레이션 잠금 획득 실패: %v", err)
+	}
+
+	if err := ensureMigrationsTable(ctx, conn); err != nil {
+		m.unlock(ctx, conn)
+		return nil, err
+	}
+	return conn, nil
+}
+
+// unlock 함수는 advisory lock을 해제하고 연결을 반환합니다.
+func (m *Migrator) unlock(ctx context.Context, conn *sql.Conn) {
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", migrationLockID); err != nil {
+		log.Printf("마이그레이션 잠금 해제 실패: %v", err)
+	}
+	conn.Close()
+}
+
+// ensureMigrationsTable 함수는 적용 이력을 기록하는 schema_migrations 테이블을 생성합니다.
+func ensureMigrationsTable(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT PRIMARY KEY,
+			name VARCHAR(255) NOT NULL,
+			checksum CHAR(64) NOT NULL,
+			applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`)
+	if err != nil {
+		return fmt.Errorf("schema_migrations 테이블 생성 실패: %v", err)
+	}
+	return nil
+}
+
+// appliedMigrations 함수는 schema_migrations 테이블의 적용 이력을 버전별로 반환합니다.
+func appliedMigrations(ctx context.Context, conn *sql.Conn) (map[int64]AppliedMigration, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, name, checksum, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("마이그레이션 이력 조회 실패: %v", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int64]AppliedMigration)
+	for rows.Next() {
+		var a AppliedMigration
+		if err := rows.Scan(&a.Version, &a.Name, &a.Checksum, &a.AppliedAt); err != nil {
+			return nil, fmt.Errorf("마이그레이션 이력 처리 실패: %v", err)
+		}
+		applied[a.Version] = a
+	}
+	return applied, rows.Err()
+}
+
+// verify 함수는 적용 이력과 파일을 비교해 누락되거나 수정된 마이그레이션을 찾습니다.
+func verify(migrations []Migration, applied map[int64]AppliedMigration) error {
+	files := make(map[int64]Migration, len(migrations))
+	for _, migration := range migrations {
+		files[migration.Version] = migration
+	}
+
+	versions := make([]int64, 0, len(applied))
+	for version := range applied {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i] < versions[j] })
+
+	for _, version := range versions {
+		a := applied[version]
+		migration, ok := files[version]
+		if !ok {
+			return fmt.Errorf("%w: %04d_%s", ErrMissingMigration, a.Version, a.Name)
+		}
+		if migration.Checksum != a.Checksum {
+			return fmt.Errorf("%w: %04d_%s (기록 %s, 파일 %s)",
+				ErrChecksumMismatch, migration.Version, migration.Name, a.Checksum[:12], migration.Checksum[:12])
+		}
+	}
+	return nil
+}
+
+// applyMigration 함수는 하나의 마이그레이션을 트랜잭션 안에서 실행하고 이력을 기록합니다.
+func applyMigration(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("트랜잭션 시작 실패: %v", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+		return fmt.Errorf("마이그레이션 %04d_%s 실행 실패: %v", migration.Version, migration.Name, err)
+	}
+
+	_, err = tx.ExecContext(ctx,
+		"INSERT INTO schema_migrations (version, name, checksum, applied_at) VALUES ($1, $2, $3, $4)",
+		migration.Version, migration.Name, migration.Checksum, time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("마이그레이션 %04d_%s 이력 기록 실패: %v", migration.Version, migration.Name, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("마이그레이션 %04d_%s 커밋 실패: %v", migration.Version, migration.Name, err)
+	}
+	return nil
+}
+
+// Down 함수는 가장 최근에 적용된 마이그레이션부터 n개를 되돌리고 되돌린 개수를 반환합니다.
+func (m *Migrator) Down(n int) (int, error) {
+	if n <= 0 {
+		return 0, fmt.Errorf("되돌릴 마이그레이션 수는 1 이상이어야 합니다: %d", n)
+	}
+
+	migrations, err := LoadMigrations(m.source)
+	if err != nil {
+		return 0, err
+	}
+
+	ctx := context.Background()
+	conn, err := m.lock(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer m.unlock(ctx, conn)
+
+	applied, err := appliedMigrations(ctx, conn)
+	if err != nil {
+		return 0, err
+	}
+	if err := verify(migrations, applied); err != nil {
+		return 0, err
+	}
+
+	// 적용된 버전을 최신순으로 되돌립니다.
+	count := 0
+	for i := len(migrations) - 1; i >= 0 && count < n; i-- {
+		migration := migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+		if migration.Down == "" {
+			return count, fmt.Errorf("마이그레이션 %04d_%s에 down 파일이 없어 되돌릴 수 없습니다", migration.Version, migration.Name)
+		}
+
+		log.Printf("마이그레이션 %04d_%s 되돌리는 중...", migration.Version, migration.Name)
+		if err := revertMigration(ctx, conn, migration); err != nil {
+			return count, err
+		}
+		count++
+	}
+
+	log.Printf("마이그레이션 %d개를 되돌렸습니다.", count)
+	return count, nil
+}
+
+// MigrationStatus 마이그레이션 파일 하나의 적용 상태입니다.
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+	Modified  bool // 적용 후 up 파일이 수정됨
+	Missing   bool // 적용 이력은 있지만 파일이 없음
+}
+
+// Status 함수는 파일과 적용 이력을 합쳐 버전 순으로 각 마이그레이션의 상태를 반환합니다.
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	migrations, err := LoadMigrations(m.source)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("DB 연결 획득 실패: %v", err)
+	}
+	defer conn.Close()
+
+	if err := ensureMigrationsTable(ctx, conn); err != nil {
+		return nil, err
+	}
+	applied, err := appliedMigrations(ctx, conn)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrations))
+	for _, migration := range migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if a, ok := applied[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = a.AppliedAt
+			status.Modified = a.Checksum != migration.Checksum
+			delete(applied, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+
+	// 파일 없이 이력만 남은 버전
+	for _, a := range applied {
+		statuses = append(statuses, MigrationStatus{
+			Version:   a.Version,
+			Name:      a.Name,
+			Applied:   true,
+			AppliedAt: a.AppliedAt,
+			Missing:   true,
+		})
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+
+	return statuses, nil
+}
+
+// CreateMigration 함수는 dir 디렉토리에 다음 버전 번호로 비어 있는 up/down 파일을 만들고 경로를 반환합니다.
+func CreateMigration(dir, name string) (string, string, error) {
+	name = strings.Trim(migrationNameReplacer.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return "", "", errors.New("마이그레이션 이름이 비어 있습니다")
+	}
+
+	migrations, err := LoadMigrations(os.DirFS(dir))
+	if err != nil {
+		return "", "", err
+	}
+
+	var version int64 = 1
+	if len(migrations) > 0 {
+		version = migrations[len(migrations)-1].Version + 1
+	}
+
+	base := fmt.Sprintf("%04d_%s", version, name)
+	upPath := filepath.Join(dir, base+".up.sql")
+	downPath := filepath.Join(dir, base+".down.sql")
+
+	if err := writeNewFile(upPath, "-- "+base+" 적용\n"); err != nil {
+		return "", "", err
+	}
+	if err := writeNewFile(downPath, "-- "+base+" 되돌리기\n"); err != nil {
+		os.Remove(upPath)
+		return "", "", err
+	}
+	return upPath, downPath, nil
+}
+
+// migrationNameReplacer 마이그레이션 이름에 쓸 수 없는 문자를 찾습니다.
+var migrationNameReplacer = regexp.MustCompile(`[^a-z0-9]+`)
+
+// writeNewFile 함수는 파일이 이미 있으면 덮어쓰지 않고 오류를 반환합니다.
+func writeNewFile(path, content string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return fmt.Errorf("마이그레이션 파일 %s 생성 실패: %v", path, err)
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(content); err != nil {
+		return fmt.Errorf("마이그레이션 파일 %s 쓰기 실패: %v", path, err)
+	}
+	return nil
+}
+
+// revertMigration 함수는 하나의 마이그레이션을 트랜잭션 안에서 되돌리고 이력을 삭제합니다.
+func revertMigration(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("트랜잭션 시작 실패: %v", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+		return fmt.Errorf("마이그레이션 %04d_%s 되돌리기 실패: %v", migration.Version, migration.Name, err)
+	}
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = $1", migration.Version); err != nil {
+		return fmt.Errorf("마이그레이션 %04d_%s 이력 삭제 실패: %v", migration.Version, migration.Name, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("마이그레이션 %04d_%s 커밋 실패: %v", migration.Version, migration.Name, err)
+	}
+	return nil
+}
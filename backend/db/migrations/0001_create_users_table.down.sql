@@ -0,0 +1,2 @@
+-- users 테이블을 삭제합니다.
+DROP TABLE IF EXISTS users;
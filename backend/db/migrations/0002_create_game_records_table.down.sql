@@ -0,0 +1,4 @@
+-- 게임 기록 테이블과 인덱스를 삭제합니다.
+DROP INDEX IF EXISTS idx_game_records_score;
+DROP INDEX IF EXISTS idx_game_records_user_id;
+DROP TABLE IF EXISTS game_records;
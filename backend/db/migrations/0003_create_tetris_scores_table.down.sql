@@ -0,0 +1,3 @@
+-- 테트리스 최고 점수 테이블과 인덱스를 삭제합니다.
+DROP INDEX IF EXISTS idx_tetris_scores_score;
+DROP TABLE IF EXISTS tetris_scores;
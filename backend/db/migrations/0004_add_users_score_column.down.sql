@@ -0,0 +1,2 @@
+-- 사용자 최고 점수 컬럼을 삭제합니다.
+ALTER TABLE users DROP COLUMN IF EXISTS score;
@@ -0,0 +1,2 @@
+-- 레거시 점수 API(UpdateScoreHandler)가 사용하는 사용자 최고 점수 컬럼을 추가합니다.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS score INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+-- 테트리스 플레이 기록 테이블과 인덱스를 삭제합니다.
+DROP INDEX IF EXISTS idx_tetris_games_user_played;
+DROP TABLE IF EXISTS tetris_games;
@@ -0,0 +1,17 @@
+-- 테트리스 플레이 기록 테이블 생성 (최고 점수 여부와 관계없이 끝난 게임을 모두 저장)
+CREATE TABLE IF NOT EXISTS tetris_games (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    score INTEGER NOT NULL DEFAULT 0,
+    lines INTEGER NOT NULL DEFAULT 0,
+    level INTEGER NOT NULL DEFAULT 1,
+    duration_ms INTEGER NOT NULL DEFAULT 0,      -- 일시정지를 제외한 플레이 시간 (밀리초)
+    pieces_placed INTEGER NOT NULL DEFAULT 0,    -- 고정한 블록 수
+    max_combo INTEGER NOT NULL DEFAULT 0,        -- 최대 연속 콤보
+    garbage_survived INTEGER NOT NULL DEFAULT 0, -- 버텨낸 가비지 라인 수
+    played_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_tetris_games_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- 사용자별 최신순 커서 페이지네이션을 위한 인덱스
+CREATE INDEX IF NOT EXISTS idx_tetris_games_user_played ON tetris_games(user_id, played_at DESC, id DESC);
@@ -0,0 +1,36 @@
+-- 레거시 저장소를 복원하고 테트리스 전용 테이블로 되돌립니다. (테트리스 외 게임 기록은 삭제됩니다)
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS score INTEGER NOT NULL DEFAULT 0;
+UPDATE users u SET score = gs.score
+FROM game_scores gs
+WHERE gs.user_id = u.id AND gs.game = 'tetris';
+
+CREATE TABLE IF NOT EXISTS game_records (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id),
+    score INTEGER NOT NULL,
+    lines INTEGER NOT NULL DEFAULT 0,
+    level INTEGER NOT NULL DEFAULT 1,
+    played_at TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_game_records_user_id ON game_records(user_id);
+CREATE INDEX IF NOT EXISTS idx_game_records_score ON game_records(score DESC);
+
+INSERT INTO game_records (user_id, score, lines, level, played_at)
+SELECT user_id, score, lines, level, played_at
+FROM game_plays
+WHERE game = 'tetris';
+
+DELETE FROM game_plays WHERE game <> 'tetris';
+DROP INDEX IF EXISTS idx_game_plays_user_played;
+ALTER TABLE game_plays DROP COLUMN game;
+ALTER TABLE game_plays RENAME TO tetris_games;
+CREATE INDEX IF NOT EXISTS idx_tetris_games_user_played ON tetris_games(user_id, played_at DESC, id DESC);
+
+DELETE FROM game_scores WHERE game <> 'tetris';
+DROP INDEX IF EXISTS idx_game_scores_game_score;
+ALTER TABLE game_scores DROP CONSTRAINT game_scores_pkey;
+ALTER TABLE game_scores DROP COLUMN game;
+ALTER TABLE game_scores ADD CONSTRAINT tetris_scores_pkey PRIMARY KEY (user_id);
+ALTER TABLE game_scores RENAME TO tetris_scores;
+CREATE INDEX IF NOT EXISTS idx_tetris_scores_score ON tetris_scores(score DESC);
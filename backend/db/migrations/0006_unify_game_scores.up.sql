@@ -0,0 +1,38 @@
+-- 테트리스 전용 점수 테이블을 게임 구분 없이 사용하는 테이블로 변경하고,
+-- 레거시 game_records / users.score 데이터를 새 테이블로 옮깁니다.
+
+-- 최고 점수: tetris_scores -> game_scores (game, user_id 당 하나)
+ALTER TABLE tetris_scores RENAME TO game_scores;
+ALTER TABLE game_scores ADD COLUMN game VARCHAR(50) NOT NULL DEFAULT 'tetris';
+ALTER TABLE game_scores ALTER COLUMN game DROP DEFAULT;
+ALTER TABLE game_scores DROP CONSTRAINT tetris_scores_pkey;
+ALTER TABLE game_scores ADD CONSTRAINT game_scores_pkey PRIMARY KEY (game, user_id);
+DROP INDEX IF EXISTS idx_tetris_scores_score;
+CREATE INDEX idx_game_scores_game_score ON game_scores(game, score DESC);
+
+-- 플레이 기록: tetris_games -> game_plays
+ALTER TABLE tetris_games RENAME TO game_plays;
+ALTER TABLE game_plays ADD COLUMN game VARCHAR(50) NOT NULL DEFAULT 'tetris';
+ALTER TABLE game_plays ALTER COLUMN game DROP DEFAULT;
+DROP INDEX IF EXISTS idx_tetris_games_user_played;
+CREATE INDEX idx_game_plays_user_played ON game_plays(game, user_id, played_at DESC, id DESC);
+
+-- 레거시 게임 기록을 테트리스 플레이 기록으로 이전
+INSERT INTO game_plays (game, user_id, score, lines, level, played_at)
+SELECT 'tetris', user_id, score, lines, level, played_at
+FROM game_records
+WHERE user_id IS NOT NULL;
+
+-- 레거시 기록이 더 높은 사용자의 최고 점수 반영
+INSERT INTO game_scores (game, user_id, score, lines, level, created_at, updated_at)
+SELECT DISTINCT ON (user_id) 'tetris', user_id, score, lines, level, played_at, played_at
+FROM game_records
+WHERE user_id IS NOT NULL
+ORDER BY user_id, score DESC, played_at ASC
+ON CONFLICT (game, user_id) DO UPDATE
+SET score = EXCLUDED.score, lines = EXCLUDED.lines, level = EXCLUDED.level, updated_at = EXCLUDED.updated_at
+WHERE game_scores.score < EXCLUDED.score;
+
+-- 더 이상 사용하지 않는 레거시 저장소 삭제
+DROP TABLE game_records;
+ALTER TABLE users DROP COLUMN IF EXISTS score;
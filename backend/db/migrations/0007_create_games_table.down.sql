@@ -0,0 +1,4 @@
+-- 게임 카탈로그 테이블과 참조 제약을 삭제합니다.
+ALTER TABLE game_plays DROP CONSTRAINT IF EXISTS fk_game_plays_game;
+ALTER TABLE game_scores DROP CONSTRAINT IF EXISTS fk_game_scores_game;
+DROP TABLE IF EXISTS games;
@@ -0,0 +1,20 @@
+-- 게임 카탈로그 테이블 생성 (게임 정의는 서버 시작 시 game 레지스트리에서 동기화됩니다)
+CREATE TABLE IF NOT EXISTS games (
+    slug VARCHAR(50) PRIMARY KEY,
+    title VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    score_order VARCHAR(4) NOT NULL DEFAULT 'desc',        -- desc: 높을수록 좋음, asc: 낮을수록 좋음
+    score_fields JSONB NOT NULL DEFAULT '[]'::jsonb,       -- 점수 제출 필드 정의
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT chk_games_score_order CHECK (score_order IN ('asc', 'desc'))
+);
+
+-- 기존 점수 데이터가 참조하는 테트리스는 미리 등록합니다.
+INSERT INTO games (slug, title, description)
+VALUES ('tetris', '테트리스', '클래식 테트리스 게임을 즐겨보세요.')
+ON CONFLICT (slug) DO NOTHING;
+
+ALTER TABLE game_scores ADD CONSTRAINT fk_game_scores_game FOREIGN KEY (game) REFERENCES games(slug);
+ALTER TABLE game_plays ADD CONSTRAINT fk_game_plays_game FOREIGN KEY (game) REFERENCES games(slug);
@@ -0,0 +1,3 @@
+-- 게임별 추가 점수 필드 컬럼을 삭제합니다.
+ALTER TABLE game_scores DROP COLUMN IF EXISTS stats;
+ALTER TABLE game_plays DROP COLUMN IF EXISTS stats;
@@ -0,0 +1,3 @@
+-- 고정 컬럼(score, lines, level 등)에 없는 게임별 점수 필드를 저장할 컬럼을 추가합니다.
+ALTER TABLE game_plays ADD COLUMN IF NOT EXISTS stats JSONB NOT NULL DEFAULT '{}'::jsonb;
+ALTER TABLE game_scores ADD COLUMN IF NOT EXISTS stats JSONB NOT NULL DEFAULT '{}'::jsonb;
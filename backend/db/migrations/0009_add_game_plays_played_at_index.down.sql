@@ -0,0 +1,2 @@
+-- 기간 조회 인덱스를 삭제합니다.
+DROP INDEX IF EXISTS idx_game_plays_game_played;
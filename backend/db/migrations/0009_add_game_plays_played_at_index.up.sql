@@ -0,0 +1,2 @@
+-- 일간/주간/월간 리더보드의 기간 조회를 위한 인덱스를 추가합니다.
+CREATE INDEX IF NOT EXISTS idx_game_plays_game_played ON game_plays(game, played_at);
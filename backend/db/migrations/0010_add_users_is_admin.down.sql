@@ -0,0 +1,2 @@
+-- 관리자 권한 컬럼을 삭제합니다.
+ALTER TABLE users DROP COLUMN IF EXISTS is_admin;
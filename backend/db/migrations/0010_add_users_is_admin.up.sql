@@ -0,0 +1,3 @@
+-- 관리자 API(시즌 관리 등) 접근 권한 컬럼을 추가합니다.
+-- 관리자 지정: UPDATE users SET is_admin = TRUE WHERE username = '...';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_admin BOOLEAN NOT NULL DEFAULT FALSE;
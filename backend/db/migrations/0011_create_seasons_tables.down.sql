@@ -0,0 +1,3 @@
+-- 시즌 관련 테이블을 삭제합니다.
+DROP TABLE IF EXISTS season_results;
+DROP TABLE IF EXISTS seasons;
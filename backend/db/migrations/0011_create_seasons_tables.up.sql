@@ -0,0 +1,35 @@
+-- 시즌 테이블 생성 (관리자가 게임별로 기간을 정의, 시즌 랭킹은 기간 내 game_plays로 집계)
+CREATE TABLE IF NOT EXISTS seasons (
+    id SERIAL PRIMARY KEY,
+    game VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    starts_at TIMESTAMP NOT NULL,            -- 포함
+    ends_at TIMESTAMP NOT NULL,              -- 미포함
+    top_n INTEGER NOT NULL DEFAULT 100,      -- 종료 시 season_results에 보관할 순위 수
+    closed_at TIMESTAMP,                     -- 최종 순위 보관 시각 (NULL이면 아직 확정되지 않음)
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_seasons_game FOREIGN KEY (game) REFERENCES games(slug),
+    CONSTRAINT chk_seasons_period CHECK (ends_at > starts_at),
+    CONSTRAINT chk_seasons_top_n CHECK (top_n > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_seasons_game_starts ON seasons(game, starts_at DESC);
+
+-- 종료된 시즌의 최종 순위 보관 테이블 (닉네임은 시즌 종료 시점의 값)
+CREATE TABLE IF NOT EXISTS season_results (
+    season_id INTEGER NOT NULL,
+    rank INTEGER NOT NULL,
+    user_id INTEGER NOT NULL,
+    username VARCHAR(50) NOT NULL,
+    nickname VARCHAR(50) NOT NULL,
+    score INTEGER NOT NULL,
+    lines INTEGER NOT NULL DEFAULT 0,
+    level INTEGER NOT NULL DEFAULT 1,
+    stats JSONB NOT NULL DEFAULT '{}'::jsonb,
+    achieved_at TIMESTAMP NOT NULL,          -- 시즌 최고 기록을 달성한 시각
+    PRIMARY KEY (season_id, user_id),
+    CONSTRAINT fk_season_results_season FOREIGN KEY (season_id) REFERENCES seasons(id) ON DELETE CASCADE,
+    CONSTRAINT fk_season_results_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_season_results_rank ON season_results(season_id, rank);
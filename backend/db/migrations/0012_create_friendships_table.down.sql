@@ -0,0 +1,2 @@
+-- 친구 관계 테이블을 삭제합니다.
+DROP TABLE IF EXISTS friendships;
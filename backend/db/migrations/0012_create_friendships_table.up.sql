@@ -0,0 +1,20 @@
+-- 친구 관계 테이블 생성 (사용자 두 명 사이에 요청 또는 친구 관계 하나만 존재)
+CREATE TABLE IF NOT EXISTS friendships (
+    requester_id INTEGER NOT NULL,                  -- 친구 요청을 보낸 사용자
+    addressee_id INTEGER NOT NULL,                  -- 친구 요청을 받은 사용자
+    status VARCHAR(10) NOT NULL DEFAULT 'pending',  -- pending: 수락 대기, accepted: 친구
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    accepted_at TIMESTAMP,
+    PRIMARY KEY (requester_id, addressee_id),
+    CONSTRAINT fk_friendships_requester FOREIGN KEY (requester_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_friendships_addressee FOREIGN KEY (addressee_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT chk_friendships_not_self CHECK (requester_id <> addressee_id),
+    CONSTRAINT chk_friendships_status CHECK (status IN ('pending', 'accepted'))
+);
+
+-- 방향과 관계없이 두 사용자 사이의 관계는 하나만 허용
+CREATE UNIQUE INDEX IF NOT EXISTS idx_friendships_pair
+    ON friendships (LEAST(requester_id, addressee_id), GREATEST(requester_id, addressee_id));
+
+-- 받은 요청/친구 목록 조회용 인덱스
+CREATE INDEX IF NOT EXISTS idx_friendships_addressee ON friendships(addressee_id, status);
@@ -0,0 +1,2 @@
+-- 게임 세션 테이블을 삭제합니다.
+DROP TABLE IF EXISTS game_sessions;
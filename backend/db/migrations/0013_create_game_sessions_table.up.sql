@@ -0,0 +1,18 @@
+-- 게임 세션 테이블 생성 (서버가 발급한 시드로 시작한 게임만 점수 제출을 허용)
+CREATE TABLE IF NOT EXISTS game_sessions (
+    id VARCHAR(32) PRIMARY KEY,              -- 무작위 세션 ID (16바이트 hex)
+    game VARCHAR(50) NOT NULL,
+    user_id INTEGER NOT NULL,
+    seed BIGINT NOT NULL,                    -- 서버가 발급한 난수 시드 (uint32)
+    started_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,           -- 이 시각 이후에는 점수를 제출할 수 없음
+    finished_at TIMESTAMP,                   -- 점수 제출 시각 (NULL이면 아직 열린 세션)
+    play_id BIGINT,                          -- 제출된 플레이 기록
+    CONSTRAINT fk_game_sessions_game FOREIGN KEY (game) REFERENCES games(slug),
+    CONSTRAINT fk_game_sessions_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_game_sessions_play FOREIGN KEY (play_id) REFERENCES game_plays(id) ON DELETE SET NULL,
+    CONSTRAINT chk_game_sessions_period CHECK (expires_at > started_at)
+);
+
+-- 사용자별 최근 세션 조회용 인덱스
+CREATE INDEX IF NOT EXISTS idx_game_sessions_user_started ON game_sessions(user_id, started_at DESC);
@@ -0,0 +1,2 @@
+-- 의심 점수 테이블을 삭제합니다.
+DROP TABLE IF EXISTS suspicious_scores;
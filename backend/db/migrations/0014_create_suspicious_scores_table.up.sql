@@ -0,0 +1,21 @@
+-- 의심 점수 테이블 생성 (부정행위 검사에 걸려 거절된 점수 제출을 사유와 함께 보관)
+CREATE TABLE IF NOT EXISTS suspicious_scores (
+    id BIGSERIAL PRIMARY KEY,
+    game VARCHAR(50) NOT NULL,
+    user_id INTEGER NOT NULL,
+    session_id VARCHAR(32),                  -- 제출에 사용한 게임 세션
+    score INTEGER NOT NULL,
+    lines INTEGER NOT NULL,
+    level INTEGER NOT NULL,
+    duration_ms INTEGER NOT NULL,
+    max_combo INTEGER NOT NULL,
+    reasons TEXT[] NOT NULL,                 -- 검사 사유 코드 목록 (예: score_inconsistent)
+    details JSONB NOT NULL DEFAULT '{}'::jsonb, -- 사유별 설명
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_suspicious_scores_game FOREIGN KEY (game) REFERENCES games(slug),
+    CONSTRAINT fk_suspicious_scores_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- 최근 의심 기록 조회용 인덱스
+CREATE INDEX IF NOT EXISTS idx_suspicious_scores_created ON suspicious_scores(created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_suspicious_scores_user ON suspicious_scores(user_id, created_at DESC);
@@ -0,0 +1,4 @@
+-- 리플레이 테이블과 최고 점수 플레이 기록 컬럼을 삭제합니다.
+ALTER TABLE game_scores DROP CONSTRAINT IF EXISTS fk_game_scores_best_play;
+ALTER TABLE game_scores DROP COLUMN IF EXISTS best_play_id;
+DROP TABLE IF EXISTS game_replays;
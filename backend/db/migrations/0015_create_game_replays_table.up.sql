@@ -0,0 +1,17 @@
+-- 리플레이 테이블 생성 (검증을 통과한 플레이 기록의 시드와 입력 기록을 압축해 보관)
+CREATE TABLE IF NOT EXISTS game_replays (
+    play_id BIGINT PRIMARY KEY,              -- 리플레이를 재생한 플레이 기록 (리플레이 ID로도 사용)
+    game VARCHAR(50) NOT NULL,
+    user_id INTEGER NOT NULL,
+    version INTEGER NOT NULL,                -- 게임 엔진 리플레이 버전
+    data BYTEA NOT NULL,                     -- 압축된 리플레이
+    size_bytes INTEGER NOT NULL,             -- 압축 전 크기
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_game_replays_play FOREIGN KEY (play_id) REFERENCES game_plays(id) ON DELETE CASCADE,
+    CONSTRAINT fk_game_replays_game FOREIGN KEY (game) REFERENCES games(slug),
+    CONSTRAINT fk_game_replays_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- 최고 점수를 달성한 플레이 기록 (리더보드에서 리플레이를 찾기 위해 사용, 기존 기록은 NULL)
+ALTER TABLE game_scores ADD COLUMN IF NOT EXISTS best_play_id BIGINT;
+ALTER TABLE game_scores ADD CONSTRAINT fk_game_scores_best_play FOREIGN KEY (best_play_id) REFERENCES game_plays(id) ON DELETE SET NULL;
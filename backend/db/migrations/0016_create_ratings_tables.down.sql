@@ -0,0 +1,3 @@
+-- 대전 레이팅 관련 테이블을 삭제합니다.
+DROP TABLE IF EXISTS battle_matches;
+DROP TABLE IF EXISTS player_ratings;
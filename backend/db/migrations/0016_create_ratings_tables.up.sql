@@ -0,0 +1,40 @@
+-- 대전 레이팅 테이블 생성 (게임별 사용자 Elo 레이팅과 전적)
+CREATE TABLE IF NOT EXISTS player_ratings (
+    game VARCHAR(50) NOT NULL,
+    user_id INTEGER NOT NULL,
+    rating INTEGER NOT NULL DEFAULT 1500,
+    wins INTEGER NOT NULL DEFAULT 0,
+    losses INTEGER NOT NULL DEFAULT 0,
+    draws INTEGER NOT NULL DEFAULT 0,
+    games_played INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (game, user_id),
+    CONSTRAINT fk_player_ratings_game FOREIGN KEY (game) REFERENCES games(slug),
+    CONSTRAINT fk_player_ratings_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- 레이팅 순위 조회용 인덱스
+CREATE INDEX IF NOT EXISTS idx_player_ratings_game_rating ON player_ratings(game, rating DESC);
+
+-- 대전 기록 테이블 생성 (대전 전후 레이팅 포함)
+CREATE TABLE IF NOT EXISTS battle_matches (
+    id BIGSERIAL PRIMARY KEY,
+    game VARCHAR(50) NOT NULL,
+    room_id VARCHAR(32) NOT NULL,
+    player1_id INTEGER NOT NULL,
+    player2_id INTEGER NOT NULL,
+    winner_id INTEGER,                       -- NULL이면 무승부
+    reason VARCHAR(20) NOT NULL,             -- topout, disconnect, timeout
+    duration_ms INTEGER NOT NULL,
+    player1_rating_before INTEGER NOT NULL,
+    player1_rating_after INTEGER NOT NULL,
+    player2_rating_before INTEGER NOT NULL,
+    player2_rating_after INTEGER NOT NULL,
+    played_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fk_battle_matches_game FOREIGN KEY (game) REFERENCES games(slug),
+    CONSTRAINT fk_battle_matches_player1 FOREIGN KEY (player1_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_battle_matches_player2 FOREIGN KEY (player2_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_battle_matches_player1 ON battle_matches(player1_id, played_at DESC);
+CREATE INDEX IF NOT EXISTS idx_battle_matches_player2 ON battle_matches(player2_id, played_at DESC);
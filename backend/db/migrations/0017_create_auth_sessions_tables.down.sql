@@ -0,0 +1,3 @@
+-- 로그인 세션과 리프레시 토큰 테이블을 삭제합니다.
+DROP TABLE IF EXISTS refresh_tokens;
+DROP TABLE IF EXISTS auth_sessions;
@@ -0,0 +1,28 @@
+-- 로그인 세션 테이블 생성 (기기별 로그인, 리프레시 토큰 묶음 하나당 한 행)
+CREATE TABLE IF NOT EXISTS auth_sessions (
+    id VARCHAR(32) PRIMARY KEY,              -- 무작위 세션 ID (16바이트 hex)
+    user_id INTEGER NOT NULL,
+    user_agent TEXT NOT NULL DEFAULT '',
+    ip VARCHAR(64) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    last_used_at TIMESTAMP NOT NULL,         -- 마지막 로그인 또는 토큰 갱신 시각
+    expires_at TIMESTAMP NOT NULL,           -- 최신 리프레시 토큰의 만료 시각
+    revoked_at TIMESTAMP,                    -- NULL이 아니면 폐기된 세션 (모든 리프레시 토큰 사용 불가)
+    revoked_reason VARCHAR(20),              -- revoked, reuse
+    CONSTRAINT fk_auth_sessions_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- 사용자별 세션 목록 조회용 인덱스
+CREATE INDEX IF NOT EXISTS idx_auth_sessions_user ON auth_sessions(user_id, last_used_at DESC);
+
+-- 리프레시 토큰 테이블 생성 (토큰 원문은 저장하지 않고 SHA-256 해시만 저장)
+CREATE TABLE IF NOT EXISTS refresh_tokens (
+    token_hash CHAR(64) PRIMARY KEY,         -- 토큰의 SHA-256 hex
+    session_id VARCHAR(32) NOT NULL,
+    issued_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,                       -- 새 토큰으로 교체된 시각 (다시 사용되면 탈취로 판단)
+    CONSTRAINT fk_refresh_tokens_session FOREIGN KEY (session_id) REFERENCES auth_sessions(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_session ON refresh_tokens(session_id);
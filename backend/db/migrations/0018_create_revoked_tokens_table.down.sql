@@ -0,0 +1,2 @@
+-- 폐기된 액세스 토큰 테이블을 삭제합니다.
+DROP TABLE IF EXISTS revoked_tokens;
@@ -0,0 +1,11 @@
+-- 폐기된 액세스 토큰 테이블 생성 (로그아웃한 JWT의 jti, 토큰 만료 시각이 지나면 삭제)
+CREATE TABLE IF NOT EXISTS revoked_tokens (
+    jti VARCHAR(64) PRIMARY KEY,             -- JWT ID (RegisteredClaims.ID)
+    user_id INTEGER NOT NULL,
+    expires_at TIMESTAMP NOT NULL,           -- 토큰 자체의 만료 시각 (이후에는 보관할 필요 없음)
+    revoked_at TIMESTAMP NOT NULL,
+    CONSTRAINT fk_revoked_tokens_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- 만료된 항목 정리용 인덱스
+CREATE INDEX IF NOT EXISTS idx_revoked_tokens_expires ON revoked_tokens(expires_at);
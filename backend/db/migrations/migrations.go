@@ -0,0 +1,9 @@
+// migrations 패키지는 스키마 마이그레이션 SQL 파일을 바이너리에 포함합니다.
+package migrations
+
+import "embed"
+
+// FS 바이너리에 포함된 마이그레이션 파일입니다. (예: 0001_create_users_table.up.sql)
+//
+//go:embed *.sql
+var FS embed.FS
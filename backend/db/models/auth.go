@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// AuthSession 기기별 로그인 세션입니다. 세션마다 리프레시 토큰이 하나씩 순서대로 발급됩니다.
+type AuthSession struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"` // 요청에 사용한 액세스 토큰의 세션인지 여부
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// 친구 관계 상태 값입니다.
+const (
+	FriendshipPending  = "pending"  // 수락 대기 중인 요청
+	FriendshipAccepted = "accepted" // 친구
+)
+
+// Friend 친구 목록과 친구 요청 목록의 한 항목입니다.
+type Friend struct {
+	UserID   int       `json:"user_id"`
+	Username string    `json:"username"`
+	Nickname string    `json:"nickname"`
+	Since    time.Time `json:"since"` // 친구가 된 시각 (요청 목록에서는 요청 시각)
+}
+
+// FriendList 사용자의 친구 목록과 대기 중인 요청입니다.
+type FriendList struct {
+	Friends  []Friend `json:"friends"`
+	Incoming []Friend `json:"incoming"` // 받은 요청
+	Outgoing []Friend `json:"outgoing"` // 보낸 요청
+}
+
+// FriendRequest 친구 요청 본문입니다. user_id 또는 nickname 중 하나로 상대를 지정합니다.
+type FriendRequest struct {
+	UserID   int    `json:"user_id"`
+	Nickname string `json:"nickname"`
+}
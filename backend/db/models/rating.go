@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// PlayerRating 게임별 사용자 대전 레이팅과 전적입니다. (player_ratings 테이블)
+type PlayerRating struct {
+	Rank        int       `json:"rank,omitempty"` // 레이팅 리더보드 조회 시 사용
+	Game        string    `json:"game"`
+	UserID      int       `json:"user_id"`
+	Username    string    `json:"username,omitempty"` // 조회 시 사용
+	Nickname    string    `json:"nickname,omitempty"` // 조회 시 사용
+	Rating      int       `json:"rating"`
+	Wins        int       `json:"wins"`
+	Losses      int       `json:"losses"`
+	Draws       int       `json:"draws"`
+	GamesPlayed int       `json:"games_played"`
+	UpdatedAt   time.Time `json:"updated_at,omitempty"`
+}
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// GameReplay 저장된 리플레이의 플레이 정보입니다. 리플레이 ID는 플레이 기록 ID와 같습니다.
+type GameReplay struct {
+	PlayID     int64     `json:"play_id"`
+	Game       string    `json:"game"`
+	UserID     int       `json:"user_id"`
+	Username   string    `json:"username"`
+	Nickname   string    `json:"nickname"`
+	Score      int       `json:"score"`
+	Lines      int       `json:"lines"`
+	Level      int       `json:"level"`
+	DurationMs int       `json:"duration_ms"`
+	PlayedAt   time.Time `json:"played_at"`
+	Version    int       `json:"version"`
+	Data       []byte    `json:"-"` // 압축된 리플레이
+}
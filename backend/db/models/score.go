@@ -1,14 +1,44 @@
 // models 패키지는 데이터 모델을 정의합니다.
 package models
 
-// ScoreRequest 점수 저장 요청 구조체
-type ScoreRequest struct {
-	Score int `json:"score" binding:"required"`
-	Lines int `json:"lines"`
-	Level int `json:"level"`
+import (
+	"time"
+)
+
+// GameScore 게임별 사용자 최고 점수 정보를 나타내는 구조체입니다. (game_scores 테이블)
+type GameScore struct {
+	Rank      int            `json:"rank,omitempty"` // 리더보드 조회 시 사용
+	Game      string         `json:"game"`
+	UserID    int            `json:"user_id"`
+	Username  string         `json:"username,omitempty"` // 조회 시 사용
+	Nickname  string         `json:"nickname,omitempty"` // 조회 시 사용
+	Score     int            `json:"score"`
+	Lines     int            `json:"lines"`
+	Level     int            `json:"level"`
+	Stats     map[string]int `json:"stats,omitempty"` // 게임별 추가 점수 필드
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	PlayID    int64          `json:"play_id,omitempty"` // 최고 점수를 달성한 플레이 기록 (리더보드 조회 시 사용)
+	HasReplay bool           `json:"has_replay"`        // 리플레이(GET /tetris/replays/:play_id) 제공 여부
+}
+
+// GamePlay 끝난 게임 한 판의 기록을 나타내는 구조체입니다. (game_plays 테이블)
+type GamePlay struct {
+	ID              int64          `json:"id"`
+	Game            string         `json:"game"`
+	UserID          int            `json:"user_id"`
+	Score           int            `json:"score"`
+	Lines           int            `json:"lines"`
+	Level           int            `json:"level"`
+	DurationMs      int            `json:"duration_ms"`
+	PiecesPlaced    int            `json:"pieces_placed"`
+	MaxCombo        int            `json:"max_combo"`
+	GarbageSurvived int            `json:"garbage_survived"`
+	Stats           map[string]int `json:"stats,omitempty"` // 게임별 추가 점수 필드
+	PlayedAt        time.Time      `json:"played_at"`
 }
 
-// ScoreResponse 점수 응답 구조체
+// ScoreResponse 점수 응답 구조체 (레거시 /user/scores API)
 type ScoreResponse struct {
 	Username string `json:"username"`
 	Nickname string `json:"nickname"`
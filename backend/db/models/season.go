@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// 시즌 상태 값입니다.
+const (
+	SeasonUpcoming = "upcoming" // 시작 전
+	SeasonActive   = "active"   // 진행 중
+	SeasonEnded    = "ended"    // 종료되었지만 최종 순위를 아직 보관하지 않음
+	SeasonClosed   = "closed"   // 최종 순위가 season_results에 보관됨
+)
+
+// Season 관리자가 정의한 게임별 시즌입니다. 시즌 기간은 [StartsAt, EndsAt)입니다.
+type Season struct {
+	ID        int        `json:"id"`
+	Game      string     `json:"game"`
+	Name      string     `json:"name"`
+	StartsAt  time.Time  `json:"starts_at"`
+	EndsAt    time.Time  `json:"ends_at"`
+	TopN      int        `json:"top_n"`
+	Status    string     `json:"status"`
+	ClosedAt  *time.Time `json:"closed_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// SeasonResult 종료된 시즌의 최종 순위 한 줄입니다.
+type SeasonResult struct {
+	SeasonID   int            `json:"season_id"`
+	Rank       int            `json:"rank"`
+	UserID     int            `json:"user_id"`
+	Username   string         `json:"username"`
+	Nickname   string         `json:"nickname"`
+	Score      int            `json:"score"`
+	Lines      int            `json:"lines"`
+	Level      int            `json:"level"`
+	Stats      map[string]int `json:"stats,omitempty"`
+	AchievedAt time.Time      `json:"achieved_at"`
+}
+
+// SeasonRequest 시즌 생성 요청입니다. 시각은 RFC 3339 형식입니다.
+type SeasonRequest struct {
+	Game     string    `json:"game"`
+	Name     string    `json:"name" binding:"required"`
+	StartsAt time.Time `json:"starts_at" binding:"required"`
+	EndsAt   time.Time `json:"ends_at" binding:"required"`
+	TopN     int       `json:"top_n"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// GameSession 서버가 발급한 게임 세션입니다. 점수는 열린(제출 전), 만료되지 않은 본인 세션으로만 제출할 수 있습니다.
+type GameSession struct {
+	ID        string    `json:"session_id"`
+	Game      string    `json:"game"`
+	UserID    int       `json:"-"`
+	Seed      uint32    `json:"seed"`
+	Signature string    `json:"signature"` // 세션 ID, 사용자, 시드에 대한 서버 서명
+	StartedAt time.Time `json:"started_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// SuspiciousScore 부정행위 검사에 걸려 거절된 점수 제출 기록입니다. (suspicious_scores 테이블)
+type SuspiciousScore struct {
+	ID         int64             `json:"id"`
+	Game       string            `json:"game"`
+	UserID     int               `json:"user_id"`
+	Username   string            `json:"username,omitempty"` // 조회 시 사용
+	Nickname   string            `json:"nickname,omitempty"` // 조회 시 사용
+	SessionID  string            `json:"session_id,omitempty"`
+	Score      int               `json:"score"`
+	Lines      int               `json:"lines"`
+	Level      int               `json:"level"`
+	DurationMs int               `json:"duration_ms"`
+	MaxCombo   int               `json:"max_combo"`
+	Reasons    []string          `json:"reasons"`
+	Details    map[string]string `json:"details,omitempty"` // 사유별 설명
+	CreatedAt  time.Time         `json:"created_at"`
+}
@@ -1,24 +1,27 @@
 package models
 
-import (
-	"time"
-)
+import "games/backend/tetris"
 
-// TetrisScore 테트리스 게임 최고 점수 정보를 나타내는 구조체입니다.
-type TetrisScore struct {
-	UserID    int       `json:"user_id"`
-	Username  string    `json:"username,omitempty"` // 조회 시 사용
-	Nickname  string    `json:"nickname,omitempty"` // 조회 시 사용
-	Score     int       `json:"score"`
-	Lines     int       `json:"lines"`
-	Level     int       `json:"level"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-}
+// TetrisScore 테트리스 게임 최고 점수 정보입니다. 게임 구분 없는 GameScore와 같은 형태입니다.
+type TetrisScore = GameScore
+
+// TetrisGame 끝난 테트리스 게임 한 판의 기록입니다. 게임 구분 없는 GamePlay와 같은 형태입니다.
+type TetrisGame = GamePlay
 
 // TetrisScoreRequest 테트리스 점수 저장 요청 구조체
+// 점수, 줄 수, 레벨은 리플레이를 서버에서 다시 재생한 결과와 같아야 하며, 나머지 기록 값은 재생 결과로 저장됩니다.
+// 리플레이 시드는 POST /tetris/sessions로 발급받은 세션의 시드여야 합니다.
+// 0점으로 끝난 게임도 기록해야 하므로 숫자 값은 binding:"required"로 두지 않습니다. (Gin은 0을 값이 없는 것으로 처리)
 type TetrisScoreRequest struct {
-	Score int `json:"score" binding:"required"`
-	Lines int `json:"lines"`
-	Level int `json:"level"`
+	SessionID        string         `json:"session_id" binding:"required"`
+	SessionSignature string         `json:"session_signature" binding:"required"`
+	Score            int            `json:"score"`
+	Lines            int            `json:"lines"`
+	Level            int            `json:"level"`
+	DurationMs       int            `json:"duration_ms"`
+	PiecesPlaced     int            `json:"pieces_placed"`
+	MaxCombo         int            `json:"max_combo"`
+	GarbageSurvived  int            `json:"garbage_survived"`
+	Replay           *tetris.Replay `json:"replay" binding:"required"`
+	SaveReplay       bool           `json:"save_replay"` // 검증된 리플레이를 저장해 리더보드에서 볼 수 있게 할지 여부
 }
@@ -15,8 +15,9 @@ type User struct {
 
 // Claims JWT Claims 구조체입니다.
 type Claims struct {
-	ID       int    `json:"id"`
-	Username string `json:"username"`
-	Nickname string `json:"nickname"`
+	ID        int    `json:"id"`
+	Username  string `json:"username"`
+	Nickname  string `json:"nickname"`
+	SessionID string `json:"sid,omitempty"` // 토큰을 발급한 로그인 세션 (auth_sessions.id)
 	jwt.RegisteredClaims
 }
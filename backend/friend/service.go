@@ -0,0 +1,250 @@
+// friend 패키지는 사용자 사이의 친구 요청과 친구 관계를 관리합니다.
+package friend
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"games/backend/db/models"
+)
+
+var (
+	// ErrUserNotFound 친구 요청 대상 사용자가 없을 때 반환됩니다.
+	ErrUserNotFound = errors.New("사용자를 찾을 수 없습니다")
+	// ErrSelf 자기 자신에게 친구 요청을 보낼 때 반환됩니다.
+	ErrSelf = errors.New("자기 자신에게는 친구 요청을 보낼 수 없습니다")
+	// ErrAlreadyFriends 이미 친구인 사용자에게 요청을 보낼 때 반환됩니다.
+	ErrAlreadyFriends = errors.New("이미 친구입니다")
+	// ErrAlreadyRequested 이미 보낸 요청이 수락 대기 중일 때 반환됩니다.
+	ErrAlreadyRequested = errors.New("이미 친구 요청을 보냈습니다")
+	// ErrRequestNotFound 수락/거절할 친구 요청이 없을 때 반환됩니다.
+	ErrRequestNotFound = errors.New("친구 요청을 찾을 수 없습니다")
+	// ErrNotFound 삭제할 친구 관계나 보낸 요청이 없을 때 반환됩니다.
+	ErrNotFound = errors.New("친구 관계를 찾을 수 없습니다")
+)
+
+// Service 친구 관계 저장소(friendships)에 접근하는 서비스입니다.
+// 두 사용자 사이에는 요청(pending) 또는 친구(accepted) 행이 하나만 존재합니다.
+type Service struct {
+	db *sql.DB
+}
+
+// NewService 함수는 친구 서비스를 생성합니다.
+func NewService(db *sql.DB) *Service {
+	return &Service{db: db}
+}
+
+// FindUserID 함수는 요청 본문의 user_id 또는 닉네임으로 대상 사용자 ID를 찾습니다.
+func (s *Service) FindUserID(req models.FriendRequest) (int, error) {
+	var id int
+	var err error
+	if req.UserID > 0 {
+		err = s.db.QueryRow("SELECT id FROM users WHERE id = $1", req.UserID).Scan(&id)
+	} else {
+		err = s.db.QueryRow("SELECT id FROM users WHERE nickname = $1", req.Nickname).Scan(&id)
+	}
+	if err == sql.ErrNoRows {
+		return 0, ErrUserNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("사용자 조회 실패: %v", err)
+	}
+	return id, nil
+}
+
+// Request 함수는 userID가 targetID에게 친구 요청을 보냅니다.
+// 상대가 이미 나에게 요청을 보낸 상태라면 그 요청을 수락한 것으로 처리하고 accepted를 반환합니다.
+func (s *Service) Request(userID, targetID int) (string, error) {
+	if userID == targetID {
+		return "", ErrSelf
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return "", fmt.Errorf("트랜잭션 시작 실패: %v", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+
+	var requesterID int
+	var status string
+	err = tx.QueryRow(
+		`SELECT requester_id, status FROM friendships
+		WHERE (requester_id = $1 AND addressee_id = $2) OR (requester_id = $2 AND addressee_id = $1)
+		FOR UPDATE`,
+		userID, targetID,
+	).Scan(&requesterID, &status)
+
+	switch {
+	case err == sql.ErrNoRows:
+		_, err = tx.Exec(
+			`INSERT INTO friendships (requester_id, addressee_id, status, created_at)
+			VALUES ($1, $2, $3, $4)`,
+			userID, targetID, models.FriendshipPending, now,
+		)
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			// 동시에 서로 요청을 보낸 경우 (고유 인덱스 충돌)
+			return "", ErrAlreadyRequested
+		}
+		if err != nil {
+			return "", fmt.Errorf("친구 요청 저장 실패: %v", err)
+		}
+		status = models.FriendshipPending
+	case err != nil:
+		return "", fmt.Errorf("친구 관계 조회 실패: %v", err)
+	case status == models.FriendshipAccepted:
+		return "", ErrAlreadyFriends
+	case requesterID == userID:
+		return "", ErrAlreadyRequested
+	default:
+		// 상대가 보낸 요청이 대기 중이면 서로 요청한 것이므로 바로 친구가 됩니다.
+		_, err = tx.Exec(
+			`UPDATE friendships SET status = $1, accepted_at = $2
+			WHERE requester_id = $3 AND addressee_id = $4`,
+			models.FriendshipAccepted, now, targetID, userID,
+		)
+		if err != nil {
+			return "", fmt.Errorf("친구 요청 수락 실패: %v", err)
+		}
+		status = models.FriendshipAccepted
+	}
+
+	if err := tx.Commit(); err != nil {
+		return "", fmt.Errorf("친구 요청 커밋 실패: %v", err)
+	}
+	return status, nil
+}
+
+// Accept 함수는 requesterID가 userID에게 보낸 친구 요청을 수락합니다.
+func (s *Service) Accept(userID, requesterID int) error {
+	result, err := s.db.Exec(
+		`UPDATE friendships SET status = $1, accepted_at = $2
+		WHERE requester_id = $3 AND addressee_id = $4 AND status = $5`,
+		models.FriendshipAccepted, time.Now(), requesterID, userID, models.FriendshipPending,
+	)
+	if err != nil {
+		return fmt.Errorf("친구 요청 수락 실패: %v", err)
+	}
+	return requireAffected(result, ErrRequestNotFound)
+}
+
+// Decline 함수는 requesterID가 userID에게 보낸 친구 요청을 거절합니다. 거절한 요청은 삭제되어 다시 보낼 수 있습니다.
+func (s *Service) Decline(userID, requesterID int) error {
+	result, err := s.db.Exec(
+		`DELETE FROM friendships
+		WHERE requester_id = $1 AND addressee_id = $2 AND status = $3`,
+		requesterID, userID, models.FriendshipPending,
+	)
+	if err != nil {
+		return fmt.Errorf("친구 요청 거절 실패: %v", err)
+	}
+	return requireAffected(result, ErrRequestNotFound)
+}
+
+// Remove 함수는 친구 관계를 끊거나 userID가 보낸 대기 중인 요청을 취소합니다.
+// 받은 요청은 Decline으로 처리합니다.
+func (s *Service) Remove(userID, otherID int) error {
+	result, err := s.db.Exec(
+		`DELETE FROM friendships
+		WHERE (status = $3 AND ((requester_id = $1 AND addressee_id = $2) OR (requester_id = $2 AND addressee_id = $1)))
+			OR (status = $4 AND requester_id = $1 AND addressee_id = $2)`,
+		userID, otherID, models.FriendshipAccepted, models.FriendshipPending,
+	)
+	if err != nil {
+		return fmt.Errorf("친구 삭제 실패: %v", err)
+	}
+	return requireAffected(result, ErrNotFound)
+}
+
+// requireAffected 함수는 변경된 행이 없으면 notFound 오류를 반환합니다.
+func requireAffected(result sql.Result, notFound error) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("처리 결과 확인 실패: %v", err)
+	}
+	if affected == 0 {
+		return notFound
+	}
+	return nil
+}
+
+// List 함수는 사용자의 친구 목록과 받은/보낸 친구 요청을 반환합니다.
+func (s *Service) List(userID int) (*models.FriendList, error) {
+	rows, err := s.db.Query(
+		`SELECT
+			f.requester_id = $1 AS outgoing,
+			f.status,
+			u.id,
+			u.username,
+			u.nickname,
+			COALESCE(f.accepted_at, f.created_at)
+		FROM friendships f
+		JOIN users u ON u.id = CASE WHEN f.requester_id = $1 THEN f.addressee_id ELSE f.requester_id END
+		WHERE f.requester_id = $1 OR f.addressee_id = $1
+		ORDER BY u.nickname ASC`,
+		userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("친구 목록 조회 실패: %v", err)
+	}
+	defer rows.Close()
+
+	list := &models.FriendList{
+		Friends:  []models.Friend{},
+		Incoming: []models.Friend{},
+		Outgoing: []models.Friend{},
+	}
+	for rows.Next() {
+		var outgoing bool
+		var status string
+		var friend models.Friend
+		if err := rows.Scan(&outgoing, &status, &friend.UserID, &friend.Username, &friend.Nickname, &friend.Since); err != nil {
+			return nil, fmt.Errorf("친구 데이터 처리 실패: %v", err)
+		}
+
+		switch {
+		case status == models.FriendshipAccepted:
+			list.Friends = append(list.Friends, friend)
+		case outgoing:
+			list.Outgoing = append(list.Outgoing, friend)
+		default:
+			list.Incoming = append(list.Incoming, friend)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("친구 목록 조회 실패: %v", err)
+	}
+	return list, nil
+}
+
+// FriendIDs 함수는 사용자의 친구(수락된 관계) ID 목록을 반환합니다.
+func (s *Service) FriendIDs(userID int) ([]int, error) {
+	rows, err := s.db.Query(
+		`SELECT CASE WHEN requester_id = $1 THEN addressee_id ELSE requester_id END
+		FROM friendships
+		WHERE (requester_id = $1 OR addressee_id = $1) AND status = $2`,
+		userID, models.FriendshipAccepted,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("친구 목록 조회 실패: %v", err)
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("친구 데이터 처리 실패: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("친구 목록 조회 실패: %v", err)
+	}
+	return ids, nil
+}
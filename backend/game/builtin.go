@@ -0,0 +1,68 @@
+package game
+
+// 포털의 기본 게임 목록입니다. 새 게임은 여기에 등록하면 카탈로그와 점수 API에 자동으로 추가됩니다.
+
+// 기본 게임 슬러그
+const (
+	Tetris   = "tetris"
+	Shooting = "shooting"
+	Snake    = "snake"
+	Puzzle   = "puzzle"
+)
+
+func init() {
+	Default.MustRegister(Game{
+		Slug:        Tetris,
+		Title:       "테트리스",
+		Description: "클래식 테트리스 게임을 즐겨보세요.",
+		Enabled:     true,
+		ScoreOrder:  OrderDesc,
+		ScoreFields: []ScoreField{
+			{Name: "score", Label: "점수", Type: "integer", Required: true},
+			{Name: "lines", Label: "라인", Type: "integer"},
+			{Name: "level", Label: "레벨", Type: "integer"},
+			{Name: "duration_ms", Label: "플레이 시간(ms)", Type: "integer"},
+			{Name: "pieces_placed", Label: "놓은 블록 수", Type: "integer"},
+			{Name: "max_combo", Label: "최대 콤보", Type: "integer"},
+			{Name: "garbage_survived", Label: "버틴 가비지 라인", Type: "integer"},
+		},
+		ScoreEndpoint: "/tetris/score", // 리플레이 검증
+	})
+
+	Default.MustRegister(Game{
+		Slug:        Shooting,
+		Title:       "슈팅 게임",
+		Description: "적 우주선을 쏘아 맞추는 슈팅 게임입니다.",
+		Enabled:     false, // 개발 중
+		ScoreOrder:  OrderDesc,
+		ScoreFields: []ScoreField{
+			{Name: "score", Label: "점수", Type: "integer", Required: true},
+			{Name: "level", Label: "스테이지", Type: "integer"},
+			{Name: "duration_ms", Label: "플레이 시간(ms)", Type: "integer"},
+		},
+	})
+
+	Default.MustRegister(Game{
+		Slug:        Snake,
+		Title:       "스네이크",
+		Description: "뱀을 조종해 먹이를 먹는 게임입니다.",
+		Enabled:     true,
+		ScoreOrder:  OrderDesc,
+		ScoreFields: []ScoreField{
+			{Name: "score", Label: "점수", Type: "integer", Required: true},
+			{Name: "duration_ms", Label: "플레이 시간(ms)", Type: "integer"},
+		},
+	})
+
+	Default.MustRegister(Game{
+		Slug:        Puzzle,
+		Title:       "퍼즐 게임",
+		Description: "두뇌를 자극하는 퍼즐 게임입니다. 더 빨리 풀수록 높은 순위입니다.",
+		Enabled:     true,
+		ScoreOrder:  OrderAsc,
+		ScoreFields: []ScoreField{
+			{Name: "score", Label: "클리어 시간(ms)", Type: "integer", Required: true},
+			{Name: "level", Label: "스테이지", Type: "integer"},
+		},
+	})
+}
@@ -0,0 +1,255 @@
+// game 패키지는 포털에서 제공하는 게임 목록(카탈로그)과 게임별 점수 규칙을 관리합니다.
+package game
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// ErrNotFound 등록되지 않은 게임일 때 반환됩니다.
+var ErrNotFound = errors.New("게임을 찾을 수 없습니다")
+
+// ScoreOrder 리더보드에서 더 좋은 점수의 방향입니다.
+type ScoreOrder string
+
+const (
+	// OrderDesc 높을수록 좋은 점수 (예: 테트리스)
+	OrderDesc ScoreOrder = "desc"
+	// OrderAsc 낮을수록 좋은 점수 (예: 클리어 시간을 겨루는 퍼즐)
+	OrderAsc ScoreOrder = "asc"
+)
+
+// ScoreField 점수 제출 시 받는 값 하나의 정의입니다.
+type ScoreField struct {
+	Name     string `json:"name"`     // 요청 JSON 키 (예: lines)
+	Label    string `json:"label"`    // 화면 표시용 이름
+	Type     string `json:"type"`     // 값 타입 (현재는 integer만 사용)
+	Required bool   `json:"required"` // 필수 여부
+}
+
+// Game 카탈로그에 등록된 게임 정보입니다.
+type Game struct {
+	Slug        string       `json:"slug"`
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	Enabled     bool         `json:"enabled"`
+	ScoreOrder  ScoreOrder   `json:"score_order"`
+	ScoreFields []ScoreField `json:"score_fields"`
+	// ScoreEndpoint 점수 검증이 필요한 게임의 전용 점수 제출 API입니다. 설정되면 범용 점수 API로는 제출할 수 없습니다.
+	ScoreEndpoint string `json:"score_endpoint,omitempty"`
+}
+
+// Better 함수는 이 게임의 점수 규칙에서 a가 b보다 좋은 점수인지 반환합니다.
+func (g Game) Better(a, b int) bool {
+	if g.ScoreOrder == OrderAsc {
+		return a < b
+	}
+	return a > b
+}
+
+// slugPattern 게임 슬러그 규칙 (URL 경로와 DB 키로 사용)
+var slugPattern = regexp.MustCompile(`^[a-z][a-z0-9-]{0,49}$`)
+
+// Registry 게임 정의를 슬러그로 관리하는 레지스트리입니다.
+type Registry struct {
+	mu    sync.RWMutex
+	games map[string]*Game
+	order []string // 등록 순서 (목록 조회 시 사용)
+}
+
+// NewRegistry 함수는 빈 게임 레지스트리를 생성합니다.
+func NewRegistry() *Registry {
+	return &Registry{games: make(map[string]*Game)}
+}
+
+// Default 애플리케이션 전체에서 사용하는 게임 레지스트리입니다. 기본 게임은 builtin.go에서 등록합니다.
+var Default = NewRegistry()
+
+// Register 함수는 게임을 레지스트리에 등록합니다.
+func (r *Registry) Register(g Game) error {
+	if !slugPattern.MatchString(g.Slug) {
+		return fmt.Errorf("잘못된 게임 슬러그입니다: %q", g.Slug)
+	}
+	if g.Title == "" {
+		return fmt.Errorf("게임 %s의 제목이 비어 있습니다", g.Slug)
+	}
+	if g.ScoreOrder == "" {
+		g.ScoreOrder = OrderDesc
+	}
+	if g.ScoreOrder != OrderDesc && g.ScoreOrder != OrderAsc {
+		return fmt.Errorf("게임 %s의 점수 정렬 방향이 잘못되었습니다: %q", g.Slug, g.ScoreOrder)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.games[g.Slug]; exists {
+		return fmt.Errorf("이미 등록된 게임입니다: %s", g.Slug)
+	}
+	r.games[g.Slug] = &g
+	r.order = append(r.order, g.Slug)
+	return nil
+}
+
+// MustRegister 함수는 Register와 같지만 실패하면 panic합니다. 패키지 초기화 시 사용합니다.
+func (r *Registry) MustRegister(g Game) {
+	if err := r.Register(g); err != nil {
+		panic(err)
+	}
+}
+
+// Get 함수는 슬러그에 해당하는 게임을 반환합니다. Enabled는 코드의 기본값이므로 현재 활성화 여부는 Lookup으로 조회합니다.
+func (r *Registry) Get(slug string) (Game, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	g, ok := r.games[slug]
+	if !ok {
+		return Game{}, false
+	}
+	return *g, true
+}
+
+// List 함수는 등록 순서대로 모든 게임을 반환합니다. (Enabled는 코드의 기본값, 현재 값은 ListCurrent)
+func (r *Registry) List() []Game {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	games := make([]Game, 0, len(r.order))
+	for _, slug := range r.order {
+		games = append(games, *r.games[slug])
+	}
+	return games
+}
+
+// Sync 함수는 등록된 게임을 games 테이블에 반영합니다.
+// 제목, 설명, 점수 규칙은 코드의 정의로 덮어쓰고, enabled 값은 처음 추가될 때만 코드의 값을 사용합니다.
+// 이후의 enabled 값은 관리자가 SetEnabled로 바꾼 DB 값이 기준이며, Lookup과 ListCurrent가 요청마다 DB에서 읽습니다.
+func (r *Registry) Sync(db *sql.DB) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	now := time.Now()
+	for _, slug := range r.order {
+		g := r.games[slug]
+
+		fields, err := json.Marshal(g.ScoreFields)
+		if err != nil {
+			return fmt.Errorf("게임 %s 점수 필드 변환 실패: %v", slug, err)
+		}
+
+		_, err = db.Exec(
+			`INSERT INTO games (slug, title, description, enabled, score_order, score_fields, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+			ON CONFLICT (slug) DO UPDATE
+			SET title = EXCLUDED.title,
+				description = EXCLUDED.description,
+				score_order = EXCLUDED.score_order,
+				score_fields = EXCLUDED.score_fields,
+				updated_at = EXCLUDED.updated_at`,
+			g.Slug, g.Title, g.Description, g.Enabled, string(g.ScoreOrder), string(fields), now,
+		)
+		if err != nil {
+			return fmt.Errorf("게임 %s 동기화 실패: %v", slug, err)
+		}
+	}
+	return nil
+}
+
+// Lookup 함수는 슬러그에 해당하는 게임을 games 테이블의 현재 enabled 값과 함께 반환합니다.
+// 다른 서버 인스턴스에서 바꾼 활성화 여부도 바로 반영되도록 레지스트리에 캐시하지 않고 매번 DB에서 읽습니다.
+func (r *Registry) Lookup(db *sql.DB, slug string) (Game, error) {
+	g, ok := r.Get(slug)
+	if !ok {
+		return Game{}, ErrNotFound
+	}
+
+	// Sync 전이라 행이 없으면 코드의 값을 사용
+	err := db.QueryRow(`SELECT enabled FROM games WHERE slug = $1`, slug).Scan(&g.Enabled)
+	if err != nil && err != sql.ErrNoRows {
+		return Game{}, fmt.Errorf("게임 %s 활성화 여부 조회 실패: %v", slug, err)
+	}
+	return g, nil
+}
+
+// ListCurrent 함수는 등록 순서대로 모든 게임을 games 테이블의 현재 enabled 값과 함께 반환합니다.
+func (r *Registry) ListCurrent(db *sql.DB) ([]Game, error) {
+	rows, err := db.Query(`SELECT slug, enabled FROM games`)
+	if err != nil {
+		return nil, fmt.Errorf("게임 활성화 여부 조회 실패: %v", err)
+	}
+	defer rows.Close()
+
+	enabled := make(map[string]bool)
+	for rows.Next() {
+		var slug string
+		var on bool
+		if err := rows.Scan(&slug, &on); err != nil {
+			return nil, fmt.Errorf("게임 데이터 처리 실패: %v", err)
+		}
+		enabled[slug] = on
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("게임 활성화 여부 조회 실패: %v", err)
+	}
+
+	games := r.List()
+	for i := range games {
+		if on, ok := enabled[games[i].Slug]; ok {
+			games[i].Enabled = on
+		}
+	}
+	return games, nil
+}
+
+// SetEnabled 함수는 games 테이블의 게임 활성화 여부를 바꿉니다.
+// Sync는 처음 추가될 때만 코드의 enabled 값을 쓰므로, 이미 등록된 게임은 이 함수로 켜고 끕니다.
+func (r *Registry) SetEnabled(db *sql.DB, slug string, enabled bool) (Game, error) {
+	g, ok := r.Get(slug)
+	if !ok {
+		return Game{}, ErrNotFound
+	}
+	_, err := db.Exec(`UPDATE games SET enabled = $1, updated_at = $2 WHERE slug = $3`, enabled, time.Now(), slug)
+	if err != nil {
+		return Game{}, fmt.Errorf("게임 %s 활성화 여부 변경 실패: %v", slug, err)
+	}
+	g.Enabled = enabled
+	return g, nil
+}
+
+// ParseScore 함수는 점수 제출 값을 이 게임의 점수 필드 정의에 따라 검증하고 정수 값으로 반환합니다.
+// 정의되지 않은 필드, 누락된 필수 필드, 정수가 아니거나 음수인 값은 오류입니다.
+func (g Game) ParseScore(values map[string]float64) (map[string]int, error) {
+	defined := make(map[string]ScoreField, len(g.ScoreFields))
+	for _, field := range g.ScoreFields {
+		defined[field.Name] = field
+	}
+
+	for name := range values {
+		if _, ok := defined[name]; !ok {
+			return nil, fmt.Errorf("%s 게임에 없는 점수 필드입니다: %s", g.Slug, name)
+		}
+	}
+
+	parsed := make(map[string]int, len(values))
+	for _, field := range g.ScoreFields {
+		value, ok := values[field.Name]
+		if !ok {
+			if field.Required {
+				return nil, fmt.Errorf("필수 점수 필드가 없습니다: %s", field.Name)
+			}
+			continue
+		}
+		if value != math.Trunc(value) || value < 0 || value > math.MaxInt32 {
+			return nil, fmt.Errorf("%s 값은 0 이상의 정수여야 합니다", field.Name)
+		}
+		parsed[field.Name] = int(value)
+	}
+	return parsed, nil
+}
@@ -0,0 +1,181 @@
+// gamesession 패키지는 서버가 발급하는 게임 세션(난수 시드와 시작 시각)을 관리합니다.
+// 점수는 본인이 시작한 열린 세션으로만 제출할 수 있으므로, 리플레이 시드와 플레이 시간을 클라이언트에게 맡기지 않습니다.
+package gamesession
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"games/backend/db/models"
+)
+
+// TTL 세션을 시작한 뒤 점수를 제출할 수 있는 시간입니다. 최대 리플레이 길이(2시간)에 일시정지 여유를 더한 값입니다.
+const TTL = 3 * time.Hour
+
+// clockSlack 플레이 시간과 서버 경과 시간을 비교할 때 허용하는 오차입니다.
+const clockSlack = 2 * time.Second
+
+var (
+	// ErrNotFound 세션이 없거나, 다른 사용자/게임의 세션이거나, 서명이 맞지 않을 때 반환됩니다.
+	ErrNotFound = errors.New("게임 세션을 찾을 수 없습니다")
+	// ErrFinished 이미 점수를 제출한 세션일 때 반환됩니다.
+	ErrFinished = errors.New("이미 점수를 제출한 게임 세션입니다")
+	// ErrExpired 제출 가능 시간이 지난 세션일 때 반환됩니다.
+	ErrExpired = errors.New("만료된 게임 세션입니다")
+	// ErrSeedMismatch 리플레이 시드가 세션에서 발급한 시드와 다를 때 반환됩니다.
+	ErrSeedMismatch = errors.New("리플레이 시드가 게임 세션의 시드와 다릅니다")
+	// ErrTooFast 플레이 시간이 세션 시작 후 실제로 흐른 시간보다 길 때 반환됩니다.
+	ErrTooFast = errors.New("플레이 시간이 게임 세션 경과 시간보다 깁니다")
+)
+
+// Service 게임 세션 저장소(game_sessions)에 접근하는 서비스입니다.
+type Service struct {
+	db     *sql.DB
+	secret []byte
+}
+
+// NewService 함수는 게임 세션 서비스를 생성합니다. secret은 세션 서명 키입니다.
+func NewService(db *sql.DB, secret []byte) *Service {
+	return &Service{db: db, secret: secret}
+}
+
+// Claim 점수 제출을 위해 닫은 세션 정보입니다.
+type Claim struct {
+	Seed    uint32
+	Elapsed time.Duration // 세션 시작부터 제출까지 서버 기준 경과 시간
+}
+
+// CheckSeed 함수는 리플레이 시드가 세션 시드와 같은지 확인합니다.
+func (c *Claim) CheckSeed(seed uint32) error {
+	if seed != c.Seed {
+		return ErrSeedMismatch
+	}
+	return nil
+}
+
+// CheckDuration 함수는 플레이 시간(일시정지 제외)이 서버 기준 경과 시간을 넘지 않는지 확인합니다.
+func (c *Claim) CheckDuration(durationMs int) error {
+	played := time.Duration(durationMs) * time.Millisecond
+	if played > c.Elapsed+clockSlack {
+		return fmt.Errorf("%w: 플레이 %v, 경과 %v", ErrTooFast, played, c.Elapsed.Round(time.Millisecond))
+	}
+	return nil
+}
+
+// Start 함수는 새 게임 세션을 발급하고 시작 시각을 기록합니다.
+func (s *Service) Start(game string, userID int) (*models.GameSession, error) {
+	var buf [20]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return nil, fmt.Errorf("세션 ID 생성 실패: %v", err)
+	}
+
+	now := time.Now()
+	session := &models.GameSession{
+		ID:        hex.EncodeToString(buf[:16]),
+		Game:      game,
+		UserID:    userID,
+		Seed:      binary.BigEndian.Uint32(buf[16:]),
+		StartedAt: now,
+		ExpiresAt: now.Add(TTL),
+	}
+	session.Signature = s.sign(session.ID, session.Game, session.UserID, session.Seed)
+
+	_, err := s.db.Exec(
+		`INSERT INTO game_sessions (id, game, user_id, seed, started_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)`,
+		session.ID, session.Game, session.UserID, int64(session.Seed), session.StartedAt, session.ExpiresAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("게임 세션 저장 실패: %v", err)
+	}
+	return session, nil
+}
+
+// Claim 함수는 점수 제출에 사용할 세션을 확인하고 닫습니다.
+// 세션은 한 번만 사용할 수 있으며, 이후 검증에 실패해도 다시 열리지 않습니다. (서버 내부 오류는 Release로 다시 엽니다)
+func (s *Service) Claim(game string, userID int, id, signature string) (*Claim, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("트랜잭션 시작 실패: %v", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+
+	var ownerID int
+	var sessionGame string
+	var seed int64
+	var finished, expired bool
+	var elapsedMs float64
+	err = tx.QueryRow(
+		`SELECT user_id, game, seed, finished_at IS NOT NULL, expires_at <= $2::timestamp,
+			EXTRACT(EPOCH FROM ($2::timestamp - started_at)) * 1000
+		FROM game_sessions
+		WHERE id = $1
+		FOR UPDATE`,
+		id, now,
+	).Scan(&ownerID, &sessionGame, &seed, &finished, &expired, &elapsedMs)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("게임 세션 조회 실패: %v", err)
+	}
+
+	// 다른 사용자의 세션인지 구분하지 않고 같은 오류로 응답
+	if ownerID != userID || sessionGame != game ||
+		!hmac.Equal([]byte(signature), []byte(s.sign(id, sessionGame, ownerID, uint32(seed)))) {
+		return nil, ErrNotFound
+	}
+	if finished {
+		return nil, ErrFinished
+	}
+	if expired {
+		return nil, ErrExpired
+	}
+
+	if _, err := tx.Exec("UPDATE game_sessions SET finished_at = $1 WHERE id = $2", now, id); err != nil {
+		return nil, fmt.Errorf("게임 세션 종료 실패: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("게임 세션 커밋 실패: %v", err)
+	}
+
+	return &Claim{
+		Seed:    uint32(seed),
+		Elapsed: time.Duration(elapsedMs) * time.Millisecond,
+	}, nil
+}
+
+// Release 함수는 서버 내부 오류로 점수를 저장하지 못했을 때 Claim으로 닫은 세션을 다시 열어 같은 세션으로 다시 제출할 수 있게 합니다.
+// 플레이 기록이 연결된 세션은 다시 열지 않습니다.
+func (s *Service) Release(id string) error {
+	if _, err := s.db.Exec("UPDATE game_sessions SET finished_at = NULL WHERE id = $1 AND play_id IS NULL", id); err != nil {
+		return fmt.Errorf("게임 세션 다시 열기 실패: %v", err)
+	}
+	return nil
+}
+
+// Attach 함수는 닫은 세션에 저장된 플레이 기록을 연결합니다.
+func (s *Service) Attach(id string, playID int64) error {
+	if _, err := s.db.Exec("UPDATE game_sessions SET play_id = $1 WHERE id = $2", playID, id); err != nil {
+		return fmt.Errorf("게임 세션 기록 연결 실패: %v", err)
+	}
+	return nil
+}
+
+// sign 함수는 세션 ID, 게임, 사용자, 시드에 대한 HMAC-SHA256 서명을 만듭니다.
+func (s *Service) sign(id, game string, userID int, seed uint32) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(id + ":" + game + ":" + strconv.Itoa(userID) + ":" + strconv.FormatUint(uint64(seed), 10)))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
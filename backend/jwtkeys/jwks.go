@@ -0,0 +1,53 @@
+package jwtkeys
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+)
+
+// JWK JSON Web Key (RFC 7517) 형식의 공개키입니다.
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`   // RSA modulus
+	E         string `json:"e,omitempty"`   // RSA exponent
+	Curve     string `json:"crv,omitempty"` // OKP 곡선 (Ed25519)
+	X         string `json:"x,omitempty"`   // OKP 공개키
+}
+
+// JWKSet /.well-known/jwks.json 응답 형식입니다.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS 함수는 폐기되지 않은 공개키 목록을 반환합니다.
+// 사용 시작 전인 키도 포함해 검증하는 쪽이 교체 전에 미리 받아 둘 수 있게 합니다. HS256 비밀키는 공개하지 않습니다.
+func (r *Ring) JWKS() JWKSet {
+	set := JWKSet{Keys: []JWK{}}
+	for _, key := range r.Keys() {
+		jwk := JWK{KeyID: key.ID, Use: "sig", Algorithm: key.Algorithm}
+		switch pub := key.verifyKey.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = encode(pub.N.Bytes())
+			jwk.E = encode(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = encode(pub)
+		default:
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
+
+// encode 함수는 JWK 값 인코딩(패딩 없는 base64url)을 합니다.
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,218 @@
+package jwtkeys
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ManifestFile 키 디렉토리에서 키 목록을 담는 파일 이름입니다.
+const ManifestFile = "keys.json"
+
+// manifest keys.json 형식입니다.
+//
+//	{"keys": [{"kid": "2025-01", "file": "2025-01.pem", "active_from": "2025-01-01T00:00:00Z", "retire_at": "2025-03-01T00:00:00Z"}]}
+type manifest struct {
+	Keys []manifestKey `json:"keys"`
+}
+
+// manifestKey keys.json의 키 항목입니다. file은 키 디렉토리 기준 상대 경로입니다.
+type manifestKey struct {
+	ID         string     `json:"kid"`
+	File       string     `json:"file"`
+	ActiveFrom time.Time  `json:"active_from"`
+	RetireAt   *time.Time `json:"retire_at,omitempty"`
+}
+
+// readManifest 함수는 키 디렉토리의 keys.json을 읽습니다. 파일이 없으면 빈 목록을 반환합니다.
+func readManifest(dir string) (*manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return &manifest{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("키 목록 읽기 실패: %v", err)
+	}
+
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("키 목록 형식 오류 (%s): %v", ManifestFile, err)
+	}
+	return &m, nil
+}
+
+// loadKeys 함수는 keys.json에 나열된 비밀키 파일을 모두 읽어 사용 시작 순으로 반환합니다.
+func loadKeys(dir string) ([]*Key, error) {
+	m, err := readManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	keys := make([]*Key, 0, len(m.Keys))
+	for _, entry := range m.Keys {
+		if entry.ID == "" || entry.File == "" {
+			return nil, fmt.Errorf("키 목록 형식 오류: kid와 file은 필수입니다")
+		}
+		if seen[entry.ID] {
+			return nil, fmt.Errorf("키 목록 형식 오류: kid %s가 중복되었습니다", entry.ID)
+		}
+		seen[entry.ID] = true
+
+		private, err := readPrivateKey(filepath.Join(dir, entry.File))
+		if err != nil {
+			return nil, fmt.Errorf("키 %s: %v", entry.ID, err)
+		}
+		var retireAt time.Time
+		if entry.RetireAt != nil {
+			retireAt = *entry.RetireAt
+		}
+		key, err := newKey(entry.ID, private, entry.ActiveFrom, retireAt)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	sortKeys(keys)
+	return keys, nil
+}
+
+// List 함수는 키 디렉토리의 모든 키(폐기된 키 포함)를 사용 시작 순으로 반환합니다.
+func List(dir string) ([]*Key, error) {
+	return loadKeys(dir)
+}
+
+// readPrivateKey 함수는 PEM 파일에서 비밀키를 읽습니다. PKCS#8과 PKCS#1(RSA) 형식을 지원합니다.
+func readPrivateKey(path string) (crypto.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("키 파일 읽기 실패: %v", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s: PEM 형식이 아닙니다", path)
+	}
+
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%s: 비밀키 해석 실패: %v", path, err)
+		}
+		return key, nil
+	case "RSA PRIVATE KEY":
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("%s: 비밀키 해석 실패: %v", path, err)
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("%s: 지원하지 않는 PEM 종류 %q", path, block.Type)
+	}
+}
+
+// Generate 함수는 새 비밀키를 만들어 키 디렉토리에 <kid>.pem으로 저장하고 keys.json에 추가합니다.
+// activeFrom 전까지는 JWKS에만 공개되고 서명에는 쓰이지 않으므로, 미리 추가해 두면 검증하는 쪽이 키를 받아 둔 뒤에 교체됩니다.
+func Generate(dir, kid, alg string, activeFrom time.Time) (*Key, error) {
+	if kid == "" || filepath.Base(kid) != kid || kid == "." || kid == ".." {
+		return nil, fmt.Errorf("kid %q는 파일 이름으로 쓸 수 없습니다", kid)
+	}
+
+	m, err := readManifest(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range m.Keys {
+		if entry.ID == kid {
+			return nil, fmt.Errorf("kid %s가 이미 있습니다", kid)
+		}
+	}
+
+	var private crypto.PrivateKey
+	switch alg {
+	case AlgEdDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	case AlgRS256:
+		private, err = rsa.GenerateKey(rand.Reader, 3072)
+	default:
+		return nil, fmt.Errorf("지원하지 않는 알고리즘 %s (%s 또는 %s)", alg, AlgEdDSA, AlgRS256)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("키 생성 실패: %v", err)
+	}
+	key, err := newKey(kid, private, activeFrom, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, fmt.Errorf("키 인코딩 실패: %v", err)
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("키 디렉토리 생성 실패: %v", err)
+	}
+	file := kid + ".pem"
+	pemData := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	if err := writeFile(filepath.Join(dir, file), pemData, 0o600); err != nil {
+		return nil, fmt.Errorf("키 파일 저장 실패: %v", err)
+	}
+
+	m.Keys = append(m.Keys, manifestKey{ID: kid, File: file, ActiveFrom: activeFrom.UTC().Truncate(time.Second)})
+	if err := writeManifest(dir, m); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Retire 함수는 키의 폐기 시각을 at으로 정합니다. 폐기된 키로 서명된 토큰은 더 이상 검증되지 않으므로
+// 보통 다음 키의 사용 시작 후 액세스 토큰 유효 기간이 지난 시각으로 정합니다.
+func Retire(dir, kid string, at time.Time) error {
+	m, err := readManifest(dir)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for i := range m.Keys {
+		if m.Keys[i].ID == kid {
+			retireAt := at.UTC().Truncate(time.Second)
+			m.Keys[i].RetireAt = &retireAt
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("kid %s를 찾을 수 없습니다", kid)
+	}
+	return writeManifest(dir, m)
+}
+
+// writeManifest 함수는 keys.json을 저장합니다.
+func writeManifest(dir string, m *manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("키 목록 인코딩 실패: %v", err)
+	}
+	if err := writeFile(filepath.Join(dir, ManifestFile), append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("키 목록 저장 실패: %v", err)
+	}
+	return nil
+}
+
+// writeFile 함수는 임시 파일에 쓴 뒤 이름을 바꿔, 실행 중인 서버가 반쯤 쓰인 파일을 읽지 않게 합니다.
+func writeFile(path string, data []byte, perm os.FileMode) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,196 @@
+// jwtkeys 패키지는 액세스 토큰(JWT) 서명 키를 관리합니다.
+// 키마다 kid와 사용 기간이 있으며, 서명에는 사용 시작 시각이 지난 가장 최근 키를 쓰고 검증은 폐기되지 않은 모든 키로 합니다.
+// 공개키는 JWKS로 공개해 다른 서비스가 비밀키 없이 토큰을 검증할 수 있습니다.
+package jwtkeys
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// 지원하는 서명 알고리즘입니다.
+const (
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+	AlgHS256 = "HS256" // 키 디렉토리를 설정하지 않았을 때의 공유 비밀키 방식 (JWKS로 공개하지 않음)
+)
+
+var (
+	// ErrNoSigningKey 지금 서명에 쓸 수 있는 키가 없을 때 반환됩니다.
+	ErrNoSigningKey = errors.New("현재 서명에 사용할 수 있는 JWT 키가 없습니다")
+	// ErrUnknownKey 토큰의 kid에 해당하는 검증 키가 없거나 알고리즘이 맞지 않을 때 반환됩니다.
+	ErrUnknownKey = errors.New("토큰을 검증할 수 있는 키가 없습니다")
+)
+
+// Key 서명 키 하나입니다.
+type Key struct {
+	ID         string
+	Algorithm  string
+	ActiveFrom time.Time // 이 시각부터 서명에 사용 (그 전에도 JWKS에는 공개해 다른 서비스가 미리 받아 둘 수 있음)
+	RetireAt   time.Time // 이 시각부터 검증과 JWKS에서 제외 (0이면 계속 사용)
+
+	signKey   interface{} // *rsa.PrivateKey, ed25519.PrivateKey, []byte(HS256)
+	verifyKey interface{} // *rsa.PublicKey, ed25519.PublicKey, []byte(HS256)
+}
+
+// retired 함수는 now에 키가 폐기되었는지 반환합니다.
+func (k *Key) retired(now time.Time) bool {
+	return !k.RetireAt.IsZero() && !now.Before(k.RetireAt)
+}
+
+// method 함수는 키 알고리즘의 jwt 서명 방식을 반환합니다.
+func (k *Key) method() jwt.SigningMethod {
+	switch k.Algorithm {
+	case AlgRS256:
+		return jwt.SigningMethodRS256
+	case AlgEdDSA:
+		return jwt.SigningMethodEdDSA
+	default:
+		return jwt.SigningMethodHS256
+	}
+}
+
+// newKey 함수는 비밀키로 서명 키를 만들고 키 종류로 알고리즘을 정합니다.
+func newKey(id string, private crypto.PrivateKey, activeFrom, retireAt time.Time) (*Key, error) {
+	key := &Key{ID: id, ActiveFrom: activeFrom, RetireAt: retireAt, signKey: private}
+	switch k := private.(type) {
+	case *rsa.PrivateKey:
+		if k.N.BitLen() < minRSABits {
+			return nil, fmt.Errorf("키 %s: RSA 키는 %d비트 이상이어야 합니다 (현재 %d비트)", id, minRSABits, k.N.BitLen())
+		}
+		key.Algorithm, key.verifyKey = AlgRS256, &k.PublicKey
+	case ed25519.PrivateKey:
+		key.Algorithm, key.verifyKey = AlgEdDSA, k.Public()
+	default:
+		return nil, fmt.Errorf("키 %s: 지원하지 않는 키 종류 %T (RSA 또는 Ed25519만 지원)", id, private)
+	}
+	return key, nil
+}
+
+// minRSABits RS256 키의 최소 크기입니다.
+const minRSABits = 2048
+
+// Ring 서명/검증 키 묶음입니다. 키 디렉토리를 쓰면 Run으로 주기적으로 다시 읽어 새로 추가한 키를 반영합니다.
+type Ring struct {
+	dir  string                 // 키 디렉토리 (HS256이면 빈 값)
+	keys atomic.Pointer[[]*Key] // ActiveFrom 순으로 정렬된 키
+}
+
+// NewHMAC 함수는 공유 비밀키 하나로 HS256 서명하는 키 묶음을 만듭니다. 토큰에 kid를 넣지 않습니다.
+func NewHMAC(secret []byte) *Ring {
+	r := &Ring{}
+	r.keys.Store(&[]*Key{{Algorithm: AlgHS256, signKey: secret, verifyKey: secret}})
+	return r
+}
+
+// LoadDir 함수는 키 디렉토리의 keys.json 목록과 PEM 비밀키 파일로 키 묶음을 만듭니다.
+func LoadDir(dir string) (*Ring, error) {
+	r := &Ring{dir: dir}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload 함수는 키 디렉토리를 다시 읽습니다. 실패하면 기존 키를 그대로 사용합니다.
+func (r *Ring) Reload() error {
+	if r.dir == "" {
+		return nil
+	}
+	keys, err := loadKeys(r.dir)
+	if err != nil {
+		return err
+	}
+	if _, err := signingKey(keys, time.Now()); err != nil {
+		return fmt.Errorf("%s: %w", r.dir, err)
+	}
+	r.keys.Store(&keys)
+	return nil
+}
+
+// Run 함수는 interval마다 키 디렉토리를 다시 읽습니다. ctx가 취소될 때까지 실행되므로 고루틴으로 호출합니다.
+// 키 디렉토리를 쓰지 않으면 바로 반환합니다. 서명 키 교체 자체는 사용 시작 시각에 맞춰 다시 읽지 않아도 일어납니다.
+func (r *Ring) Run(ctx context.Context, interval time.Duration) {
+	if r.dir == "" {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Reload(); err != nil {
+				log.Printf("JWT 키 다시 읽기 실패 (기존 키 사용): %v", err)
+			}
+		}
+	}
+}
+
+// Sign 함수는 지금 사용할 서명 키로 claims를 서명합니다. 비대칭 키면 헤더에 kid를 넣습니다.
+func (r *Ring) Sign(claims jwt.Claims) (string, error) {
+	key, err := signingKey(*r.keys.Load(), time.Now())
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(key.method(), claims)
+	if key.ID != "" {
+		token.Header["kid"] = key.ID
+	}
+	return token.SignedString(key.signKey)
+}
+
+// Keyfunc 함수는 jwt.Parse에 넘기는 검증 키 조회 함수입니다.
+// 토큰 헤더의 kid와 알고리즘이 모두 맞는 폐기되지 않은 키만 반환해, 공개키를 HMAC 비밀키로 쓰는 알고리즘 혼동 공격을 막습니다.
+func (r *Ring) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	now := time.Now()
+	for _, key := range *r.keys.Load() {
+		if key.ID == kid && key.Algorithm == token.Method.Alg() && !key.retired(now) {
+			return key.verifyKey, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: kid %q, alg %s", ErrUnknownKey, kid, token.Method.Alg())
+}
+
+// Keys 함수는 폐기되지 않은 키 목록을 사용 시작 순으로 반환합니다.
+func (r *Ring) Keys() []*Key {
+	now := time.Now()
+	var keys []*Key
+	for _, key := range *r.keys.Load() {
+		if !key.retired(now) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// signingKey 함수는 now에 서명에 쓸 키(사용 시작 시각이 지난 폐기되지 않은 키 중 가장 최근 키)를 반환합니다.
+func signingKey(keys []*Key, now time.Time) (*Key, error) {
+	for i := len(keys) - 1; i >= 0; i-- {
+		if !keys[i].ActiveFrom.After(now) && !keys[i].retired(now) {
+			return keys[i], nil
+		}
+	}
+	return nil, ErrNoSigningKey
+}
+
+// sortKeys 함수는 키를 사용 시작 순으로 정렬합니다.
+func sortKeys(keys []*Key) {
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i].ActiveFrom.Before(keys[j].ActiveFrom)
+	})
+}
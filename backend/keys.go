@@ -0,0 +1,105 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"games/backend/config"
+	"games/backend/jwtkeys"
+)
+
+// runKeys 함수는 keys 서브커맨드(create <kid>, retire <kid>, list)로 JWT 서명 키 디렉토리를 관리합니다.
+// 실행 중인 서버는 키 디렉토리를 주기적으로 다시 읽으므로 재시작 없이 새 키가 반영됩니다.
+func runKeys(args []string) {
+	flags := flag.NewFlagSet("keys", flag.ExitOnError)
+	dir := flags.String("dir", "", "JWT 키 디렉토리 (기본값: JWT_KEYS_DIR)")
+	alg := flags.String("alg", jwtkeys.AlgEdDSA, "create: 서명 알고리즘 (EdDSA 또는 RS256)")
+	activeFrom := flags.String("active-from", "", "create: 서명에 사용하기 시작할 시각 (RFC 3339, 기본값: 지금)")
+	at := flags.String("at", "", "retire: 폐기 시각 (RFC 3339, 기본값: 지금)")
+	configFlags := config.RegisterFlags(flags, "env")
+	flags.Parse(args)
+	args = flags.Args()
+
+	if len(args) == 0 {
+		printUsage()
+		os.Exit(2)
+	}
+	if *dir == "" {
+		*dir = loadConfig(configFlags, "auth.jwt_keys_dir").Auth.JWTKeysDir
+	}
+	if *dir == "" {
+		log.Fatal("키 디렉토리를 지정하세요: -dir 또는 JWT_KEYS_DIR")
+	}
+
+	switch args[0] {
+	case "create":
+		if len(args) < 2 {
+			log.Fatal("키 ID를 지정하세요: keys create <kid>")
+		}
+		from, err := parseKeyTime(*activeFrom)
+		if err != nil {
+			log.Fatal("잘못된 -active-from 값입니다:", err)
+		}
+		key, err := jwtkeys.Generate(*dir, args[1], *alg, from)
+		if err != nil {
+			log.Fatal("JWT 키 생성 실패:", err)
+		}
+		fmt.Printf("생성됨: %s (%s, %s부터 서명에 사용)\n", key.ID, key.Algorithm, key.ActiveFrom.Format(time.RFC3339))
+
+	case "retire":
+		if len(args) < 2 {
+			log.Fatal("키 ID를 지정하세요: keys retire <kid>")
+		}
+		retireAt, err := parseKeyTime(*at)
+		if err != nil {
+			log.Fatal("잘못된 -at 값입니다:", err)
+		}
+		if err := jwtkeys.Retire(*dir, args[1], retireAt); err != nil {
+			log.Fatal("JWT 키 폐기 실패:", err)
+		}
+		fmt.Printf("폐기 예정: %s (%s)\n", args[1], retireAt.Format(time.RFC3339))
+
+	case "list":
+		keys, err := jwtkeys.List(*dir)
+		if err != nil {
+			log.Fatal("JWT 키 목록 조회 실패:", err)
+		}
+		printKeys(keys)
+
+	default:
+		fmt.Fprintf(os.Stderr, "알 수 없는 keys 명령입니다: %s\n\n", args[0])
+		printUsage()
+		os.Exit(2)
+	}
+}
+
+// parseKeyTime 함수는 RFC 3339 시각을 해석합니다. 빈 값이면 지금 시각을 반환합니다.
+func parseKeyTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Now(), nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// printKeys 함수는 JWT 키 목록과 현재 상태를 표 형태로 출력합니다.
+func printKeys(keys []*jwtkeys.Key) {
+	now := time.Now()
+	fmt.Printf("%-20s %-6s %-10s %-25s %s\n", "KID", "ALG", "STATE", "ACTIVE FROM", "RETIRE AT")
+	for _, key := range keys {
+		state := "active"
+		switch {
+		case !key.RetireAt.IsZero() && !now.Before(key.RetireAt):
+			state = "retired"
+		case key.ActiveFrom.After(now):
+			state = "upcoming"
+		}
+		retireAt := "-"
+		if !key.RetireAt.IsZero() {
+			retireAt = key.RetireAt.Format(time.RFC3339)
+		}
+		fmt.Printf("%-20s %-6s %-10s %-25s %s\n", key.ID, key.Algorithm, state, key.ActiveFrom.Format(time.RFC3339), retireAt)
+	}
+}
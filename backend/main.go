@@ -1,8 +1,13 @@
-// 메인 패키지입니다. 이 파일에서는 서버 실행, 데이터베이스 연결 설정, HTTP 라우터 설정을 담당합니다.
+// 메인 패키지입니다. 이 파일에서는 서브커맨드 분기, 서버 실행, 데이터베이스 연결 설정, HTTP 라우터 설정을 담당합니다.
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
+	"io/fs"
 	"log"
+	"net/http"
 	"os"
 	"strings"
 	"time"
@@ -14,6 +19,8 @@ import (
 	"games/backend/api"    // API 핸들러
 	"games/backend/config" // 설정
 	"games/backend/db"     // 데이터베이스
+	"games/backend/game"   // 게임 카탈로그
+	"games/backend/web"    // 프론트엔드 정적 파일
 	// 미들웨어
 )
 
@@ -25,31 +32,93 @@ func main() {
 		// 치명적 오류가 아니므로 계속 진행
 	}
 
-	// 설정 초기화
-	config.InitConfig()
+	// 서브커맨드 분기 (인자가 없으면 서버 실행)
+	command := "serve"
+	args := os.Args[1:]
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
+
+	switch command {
+	case "serve":
+		runServe(args)
+	case "migrate":
+		runMigrate(args)
+	case "keys":
+		runKeys(args)
+	case "help", "-h", "--help":
+		printUsage()
+	default:
+		fmt.Fprintf(os.Stderr, "알 수 없는 명령입니다: %s\n\n", command)
+		printUsage()
+		os.Exit(2)
+	}
+}
+
+// printUsage 함수는 바이너리 사용법을 출력합니다.
+func printUsage() {
+	fmt.Fprint(os.Stderr, `사용법:
+  backend [serve] [-config file]     HTTP 서버 실행 (기본 명령, 플래그는 serve -h 참고)
+  backend migrate up                 적용되지 않은 마이그레이션 모두 적용
+  backend migrate down N             최근 적용된 마이그레이션 N개 되돌리기
+  backend migrate status             마이그레이션 적용 상태 출력
+  backend migrate create <name>      새 up/down 마이그레이션 파일 생성
+  backend keys create <kid>          새 JWT 서명 키 생성 (-alg EdDSA|RS256, -active-from, -dir)
+  backend keys retire <kid>          JWT 서명 키 폐기 시각 지정 (-at, -dir, 그 이후 이 키의 토큰은 검증하지 않음)
+  backend keys list                  JWT 서명 키 목록 출력
+`)
+}
+
+// loadConfig 함수는 설정 파일, 환경 변수, 플래그에서 설정을 읽습니다.
+// 운영 환경에서 빠졌거나 약한 설정이 있으면 문제가 된 설정을 모두 출력하고 종료합니다. keys를 지정하면 그 설정만 검증합니다.
+func loadConfig(flags *config.Flags, keys ...string) *config.Config {
+	cfg, err := config.Load(flags, keys...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return cfg
+}
+
+// runServe 함수는 DB에 연결하고 Gin 서버를 실행합니다.
+func runServe(args []string) {
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	configFlags := config.RegisterFlags(flags)
+	flags.Parse(args)
+	cfg := loadConfig(configFlags)
+
+	if cfg.Database.MigrationsDir != "" {
+		db.SetMigrationsDir(cfg.Database.MigrationsDir)
+	}
+
+	// JWT 서명 키 로드 (키 디렉토리를 설정하지 않으면 JWT 시크릿으로 HS256 서명)
+	keys, err := cfg.Auth.SigningKeys()
+	if err != nil {
+		log.Fatal("JWT 키 로드 실패:", err)
+	}
 
 	// DB 초기화
-	db.InitDB()
+	db.InitDB(cfg.Database)
+
+	// 마이그레이션 적용 또는 검증 (수정된 마이그레이션 파일이 있으면 시작을 거부합니다)
+	if cfg.Database.Migrate {
+		if err := db.RunMigrations(); err != nil {
+			log.Fatal("마이그레이션 실패:", err)
+		}
+	} else if err := db.VerifyMigrations(); err != nil {
+		log.Fatal("마이그레이션 검증 실패:", err)
+	}
+
+	// 게임 카탈로그 동기화 (레지스트리 -> games 테이블)
+	if err := game.Default.Sync(db.DB); err != nil {
+		log.Fatal("게임 카탈로그 동기화 실패:", err)
+	}
 
 	// Gin 라우터 생성
 	router := gin.Default()
 
 	// CORS 미들웨어 추가 (개발 및 프로덕션 환경 모두 지원)
 	router.Use(cors.New(cors.Config{
-		AllowOrigins: []string{
-			// 로컬 개발 환경 포트들
-			"http://localhost:3000", "http://127.0.0.1:3000",
-			"http://localhost:8000", "http://127.0.0.1:8000",
-			"http://localhost:5500", "http://127.0.0.1:5500",
-			"http://localhost:8080", "http://127.0.0.1:8080",
-			"http://localhost:8081", "http://127.0.0.1:8081",
-			"http://localhost:4000", "http://127.0.0.1:4000",
-			"http://localhost", "http://127.0.0.1",
-
-			// 프로덕션 도메인
-			"https://kakaotech.my", "https://www.kakaotech.my",
-			"http://kakaotech.my", "http://www.kakaotech.my",
-		},
+		AllowOrigins:     cfg.CORS.AllowedOrigins,
 		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
 		AllowHeaders:     []string{"Origin", "Content-Type", "Authorization"},
 		ExposeHeaders:    []string{"Content-Length"},
@@ -58,7 +127,10 @@ func main() {
 	}))
 
 	// API 라우트 설정
-	api.SetupRoutes(router)
+	api.SetupRoutes(router, cfg, keys)
+
+	// 백그라운드 작업 시작 (종료된 시즌의 최종 순위 보관, 대전 허브, 만료된 토큰 폐기 기록 정리)
+	api.StartBackgroundJobs(context.Background())
 
 	// 정적 파일 서빙 시 캐시 버스팅을 위한 미들웨어
 	router.Use(func(c *gin.Context) {
@@ -74,41 +146,62 @@ func main() {
 		c.Next()
 	})
 
-	// 정적 파일 서빙
-	router.Static("/assets", "./frontend/assets")
-	router.Static("/auth", "./frontend/auth")
-	router.Static("/menu", "./frontend/menu")
-	router.Static("/games", "./frontend/games")
+	// 정적 파일 서빙 (기본값: 바이너리에 포함된 프론트엔드)
+	staticFS, err := web.FS(cfg.Server.StaticDir)
+	if err != nil {
+		log.Fatal("정적 파일 설정 실패:", err)
+	}
+	setupStatic(router, staticFS)
+
+	// 서버 실행
+	log.Printf("서버가 http://localhost:%d 에서 실행 중입니다 (%s 환경)", cfg.Server.Port, cfg.Env)
+	router.Run(fmt.Sprintf(":%d", cfg.Server.Port))
+}
 
-	// 메인 페이지
-	router.GET("/", func(c *gin.Context) {
+// setupStatic 함수는 프론트엔드 정적 파일과 메인 페이지 라우트를 설정합니다.
+// API 라우트(/games/:slug 등)와 경로가 겹치지 않도록 정적 파일은 일치하는 라우트가 없을 때만 제공합니다.
+func setupStatic(router *gin.Engine, staticFS fs.FS) {
+	fileServer := http.FileServer(web.HTTPFS(staticFS, "."))
+
+	// index.html은 http.FileServer의 리다이렉트를 피하기 위해 직접 읽어서 응답합니다.
+	serveIndex := func(c *gin.Context) {
+		index, err := fs.ReadFile(staticFS, "index.html")
+		if err != nil {
+			c.JSON(http.StatusNotFound, gin.H{"message": "메인 페이지를 찾을 수 없습니다"})
+			return
+		}
 		c.Header("Cache-Control", "no-cache, no-store, must-revalidate")
-		c.File("./frontend/index.html")
-	})
-	router.StaticFile("/favicon.ico", "./frontend/favicon.ico")
+		c.Data(http.StatusOK, "text/html; charset=utf-8", index)
+	}
+
+	// 메인 페이지
+	router.GET("/", serveIndex)
 
 	// 1. 특정 URL에 대한 리다이렉션
 	router.GET("/login.html", func(c *gin.Context) {
 		c.Redirect(301, "/auth/login.html")
 	})
 
-	// 존재하지 않는 경로 처리 (SPA 지원)
+	// 정적 파일 및 존재하지 않는 경로 처리 (SPA 지원)
 	router.NoRoute(func(c *gin.Context) {
-		// API 경로가 아니면 index.html로 리다이렉션
-		if len(c.Request.URL.Path) < 4 || c.Request.URL.Path[:4] != "/api" {
-			c.File("./frontend/index.html")
+		path := c.Request.URL.Path
+
+		// API 경로는 404 반환
+		if strings.HasPrefix(path, "/api") {
+			c.JSON(404, gin.H{"message": "API 경로를 찾을 수 없습니다"})
 			return
 		}
-		// API 경로는 404 반환
-		c.JSON(404, gin.H{"message": "API 경로를 찾을 수 없습니다"})
-	})
 
-	// 서버 포트 설정 및 실행
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080" // 기본 포트
-	}
+		// 프론트엔드 파일이 있으면 그대로 제공
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			name := strings.TrimPrefix(path, "/")
+			if info, err := fs.Stat(staticFS, name); err == nil && !info.IsDir() {
+				fileServer.ServeHTTP(c.Writer, c.Request)
+				return
+			}
+		}
 
-	log.Printf("서버가 http://localhost:%s 에서 실행 중입니다", port)
-	router.Run(":" + port)
+		// 그 외에는 index.html 제공
+		serveIndex(c)
+	})
 }
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"games/backend/db"
+)
+
+// AdminMiddleware 관리자 전용 API 미들웨어입니다. AuthMiddleware 뒤에 사용해야 합니다.
+// 권한 변경이 바로 반영되도록 토큰이 아닌 users.is_admin 값을 매 요청마다 확인합니다.
+func AdminMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, exists := c.Get("userID")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"message": "인증되지 않은 사용자"})
+			c.Abort()
+			return
+		}
+
+		var isAdmin bool
+		err := db.DB.QueryRow("SELECT is_admin FROM users WHERE id = $1", userID).Scan(&isAdmin)
+		if err != nil || !isAdmin {
+			c.JSON(http.StatusForbidden, gin.H{"message": "관리자 권한이 필요합니다."})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
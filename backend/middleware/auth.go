@@ -2,29 +2,51 @@
 package middleware
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/gorilla/websocket"
 
-	"games/backend/config"
 	"games/backend/db/models"
+	"games/backend/jwtkeys"
 )
 
+// RevocationChecker 만료 전에 폐기된 토큰 ID(jti)를 확인하는 저장소입니다.
+type RevocationChecker interface {
+	IsRevoked(jti string, expiresAt time.Time) (bool, error)
+}
+
+// Revocations AuthMiddleware가 확인할 토큰 폐기 저장소입니다. nil이면 폐기 여부를 확인하지 않습니다. (api.SetupRoutes에서 설정)
+var Revocations RevocationChecker
+
 // AuthMiddleware JWT 기반 인증 미들웨어입니다.
-func AuthMiddleware() gin.HandlerFunc {
+// 브라우저는 WebSocket 연결에 헤더를 붙일 수 없으므로 WebSocket 업그레이드 요청만 token 쿼리 파라미터도 허용합니다.
+// 만료되었거나 로그아웃으로 폐기된 토큰은 401, 그 밖의 잘못된 토큰은 403으로 거절합니다.
+// 토큰은 keys의 키 중 토큰 헤더의 kid와 알고리즘이 맞는 키로 검증합니다.
+func AuthMiddleware(keys *jwtkeys.Ring) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Authorization 헤더에서 "Bearer {토큰}" 형식의 토큰을 추출합니다.
 		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
+		queryToken := ""
+		if websocket.IsWebSocketUpgrade(c.Request) {
+			queryToken = c.Query("token")
+		}
+		if authHeader == "" && queryToken == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{"message": "토큰이 제공되지 않았습니다."})
 			c.Abort()
 			return
 		}
 
 		var tokenString string
-		fmt.Sscanf(authHeader, "Bearer %s", &tokenString)
+		if authHeader != "" {
+			fmt.Sscanf(authHeader, "Bearer %s", &tokenString)
+		} else {
+			tokenString = queryToken
+		}
 		if tokenString == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{"message": "잘못된 인증 형식입니다."})
 			c.Abort()
@@ -32,27 +54,68 @@ func AuthMiddleware() gin.HandlerFunc {
 		}
 
 		// JWT 토큰을 파싱하고 검증합니다.
-		token, err := jwt.ParseWithClaims(tokenString, &models.Claims{}, func(token *jwt.Token) (interface{}, error) {
-			return config.JWTSecret, nil
-		})
+		// 검증 키는 토큰 헤더의 kid와 알고리즘으로 고릅니다. (폐기된 키나 알고리즘이 다른 토큰은 거부)
+		token, err := jwt.ParseWithClaims(tokenString, &models.Claims{}, keys.Keyfunc)
 
+		// 만료된 토큰은 클라이언트가 POST /auth/refresh로 다시 발급받을 수 있도록 401로 구분합니다.
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			c.JSON(http.StatusUnauthorized, gin.H{"message": "토큰이 만료되었습니다."})
+			c.Abort()
+			return
+		}
 		if err != nil || !token.Valid {
 			c.JSON(http.StatusForbidden, gin.H{"message": "토큰이 유효하지 않습니다."})
 			c.Abort()
 			return
 		}
 
-		// 토큰의 클레임 정보를 Gin 컨텍스트에 저장합니다.
-		if claims, ok := token.Claims.(*models.Claims); ok {
-			c.Set("userID", claims.ID)
-			c.Set("username", claims.Username)
-			c.Set("nickname", claims.Nickname)
-		} else {
+		claims, ok := token.Claims.(*models.Claims)
+		if !ok {
 			c.JSON(http.StatusForbidden, gin.H{"message": "토큰 정보가 올바르지 않습니다."})
 			c.Abort()
 			return
 		}
 
+		// 로그아웃 등으로 폐기된 토큰인지 확인합니다. (jti가 없는 이전 토큰은 개별 폐기할 수 없음)
+		var expiresAt time.Time
+		if claims.ExpiresAt != nil {
+			expiresAt = claims.ExpiresAt.Time
+		}
+		if jti := claims.RegisteredClaims.ID; jti != "" && Revocations != nil {
+			revoked, err := Revocations.IsRevoked(jti, expiresAt)
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"message": "토큰 확인에 실패했습니다."})
+				c.Abort()
+				return
+			}
+			if revoked {
+				c.JSON(http.StatusUnauthorized, gin.H{"message": "로그아웃된 토큰입니다."})
+				c.Abort()
+				return
+			}
+		}
+
+		// 토큰의 클레임 정보를 Gin 컨텍스트에 저장합니다.
+		c.Set("userID", claims.ID)
+		c.Set("username", claims.Username)
+		c.Set("nickname", claims.Nickname)
+		c.Set("sessionID", claims.SessionID)
+		c.Set("tokenID", claims.RegisteredClaims.ID)
+		c.Set("tokenExpiresAt", expiresAt)
+
+		c.Next()
+	}
+}
+
+// AuthWhen 함수는 cond가 true인 요청에만 AuthMiddleware를 적용합니다.
+// 공개 API 중 일부 파라미터(예: 친구 리더보드의 scope=friends)만 로그인이 필요할 때 사용합니다.
+func AuthWhen(keys *jwtkeys.Ring, cond func(c *gin.Context) bool) gin.HandlerFunc {
+	auth := AuthMiddleware(keys)
+	return func(c *gin.Context) {
+		if cond(c) {
+			auth(c)
+			return
+		}
 		c.Next()
 	}
 }
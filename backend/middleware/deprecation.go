@@ -0,0 +1,16 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// Deprecated 더 이상 권장하지 않는 API 응답에 Deprecation 헤더와 대체 API 링크를 추가하는 미들웨어입니다.
+func Deprecated(successor string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Deprecation", "true")
+		if successor != "" {
+			c.Header("Link", "<"+successor+`>; rel="successor-version"`)
+		}
+		c.Next()
+	}
+}
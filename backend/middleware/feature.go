@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Feature 설정에서 끈 기능(enabled가 false)의 API를 404로 응답하는 미들웨어입니다.
+func Feature(enabled bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !enabled {
+			c.JSON(http.StatusNotFound, gin.H{"message": "사용하지 않는 기능입니다."})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"games/backend/config"
+	"games/backend/db"
+)
+
+// runMigrate 함수는 migrate 서브커맨드(up, down N, status, create <name>)를 처리합니다.
+func runMigrate(args []string) {
+	flags := flag.NewFlagSet("migrate", flag.ExitOnError)
+	dir := flags.String("dir", "", "포함된 파일 대신 디스크에서 마이그레이션을 읽을 디렉토리 (기본값: MIGRATIONS_DIR, create는 "+db.MigrationsDir+")")
+	configFlags := config.RegisterFlags(flags, "env", "database-url")
+	flags.Parse(args)
+	args = flags.Args()
+
+	if len(args) == 0 {
+		printUsage()
+		os.Exit(2)
+	}
+
+	// 마이그레이션은 비밀키 등을 쓰지 않으므로 DB 설정만 검증
+	cfg := loadConfig(configFlags, "database")
+	if *dir == "" {
+		*dir = cfg.Database.MigrationsDir
+	}
+	if *dir != "" && args[0] != "create" {
+		db.SetMigrationsDir(*dir)
+	}
+
+	switch args[0] {
+	case "up":
+		db.InitDB(cfg.Database)
+		if err := db.RunMigrations(); err != nil {
+			log.Fatal("마이그레이션 실패:", err)
+		}
+
+	case "down":
+		if len(args) < 2 {
+			log.Fatal("되돌릴 마이그레이션 수를 지정하세요: migrate down N")
+		}
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n <= 0 {
+			log.Fatalf("잘못된 마이그레이션 수입니다: %s", args[1])
+		}
+
+		db.InitDB(cfg.Database)
+		if _, err := db.NewMigrator(db.DB, db.MigrationSource()).Down(n); err != nil {
+			log.Fatal("마이그레이션 되돌리기 실패:", err)
+		}
+
+	case "status":
+		db.InitDB(cfg.Database)
+		statuses, err := db.NewMigrator(db.DB, db.MigrationSource()).Status()
+		if err != nil {
+			log.Fatal("마이그레이션 상태 조회 실패:", err)
+		}
+		printMigrationStatus(statuses)
+
+	case "create":
+		if len(args) < 2 {
+			log.Fatal("마이그레이션 이름을 지정하세요: migrate create <name>")
+		}
+		createDir := db.MigrationsDir
+		if *dir != "" {
+			createDir = *dir
+		}
+		upPath, downPath, err := db.CreateMigration(createDir, args[1])
+		if err != nil {
+			log.Fatal("마이그레이션 파일 생성 실패:", err)
+		}
+		fmt.Println("생성됨:", upPath)
+		fmt.Println("생성됨:", downPath)
+
+	default:
+		fmt.Fprintf(os.Stderr, "알 수 없는 migrate 명령입니다: %s\n\n", args[0])
+		printUsage()
+		os.Exit(2)
+	}
+}
+
+// printMigrationStatus 함수는 마이그레이션 상태를 표 형태로 출력합니다.
+func printMigrationStatus(statuses []db.MigrationStatus) {
+	fmt.Printf("%-8s %-40s %-10s %s\n", "VERSION", "NAME", "STATE", "APPLIED AT")
+	for _, status := range statuses {
+		state := "pending"
+		appliedAt := "-"
+		if status.Applied {
+			state = "applied"
+			appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		if status.Modified {
+			state = "modified"
+		}
+		if status.Missing {
+			state = "missing"
+		}
+		fmt.Printf("%04d     %-40s %-10s %s\n", status.Version, status.Name, state, appliedAt)
+	}
+}
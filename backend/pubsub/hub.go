@@ -0,0 +1,107 @@
+// pubsub 패키지는 서버 프로세스 안에서 주제별 메시지를 여러 구독자에게 나눠 보내는 허브를 제공합니다.
+// 여러 서버 인스턴스 사이의 전달은 하지 않습니다.
+package pubsub
+
+import "sync"
+
+// Hub 주제별 구독자 목록을 관리하고 발행된 메시지를 모든 구독자에게 나눠 보냅니다.
+// 메시지는 최신 상태를 나타낸다고 가정합니다. 구독자가 이전 메시지를 아직 받지 않았으면 그 메시지를 버리고 새 메시지로 바꾸므로,
+// 느린 구독자 때문에 발행자가 멈추지 않습니다.
+type Hub[T any] struct {
+	mu     sync.Mutex
+	topics map[string]map[*Subscription[T]]struct{}
+	closed bool
+}
+
+// Subscription 한 구독자의 구독입니다. C에서 메시지를 받고, 끝나면 Close를 호출해야 합니다.
+type Subscription[T any] struct {
+	hub   *Hub[T]
+	topic string
+	ch    chan T
+}
+
+// NewHub 함수는 빈 허브를 생성합니다.
+func NewHub[T any]() *Hub[T] {
+	return &Hub[T]{topics: map[string]map[*Subscription[T]]struct{}{}}
+}
+
+// Subscribe 함수는 topic을 구독합니다. 허브가 닫혔으면 C가 이미 닫힌 구독을 반환합니다.
+func (h *Hub[T]) Subscribe(topic string) *Subscription[T] {
+	sub := &Subscription[T]{hub: h, topic: topic, ch: make(chan T, 1)}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		close(sub.ch)
+		return sub
+	}
+	if h.topics[topic] == nil {
+		h.topics[topic] = map[*Subscription[T]]struct{}{}
+	}
+	h.topics[topic][sub] = struct{}{}
+	return sub
+}
+
+// Publish 함수는 topic의 모든 구독자에게 msg를 보내고, 받은 구독자 수를 반환합니다. 구독자를 기다리지 않습니다.
+func (h *Hub[T]) Publish(topic string, msg T) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.topics[topic] {
+		// 받지 않은 이전 메시지가 있으면 버리고 최신 메시지로 교체
+		select {
+		case <-sub.ch:
+		default:
+		}
+		select {
+		case sub.ch <- msg:
+		default:
+		}
+	}
+	return len(h.topics[topic])
+}
+
+// Subscribers 함수는 topic의 현재 구독자 수를 반환합니다. 구독자가 없으면 발행할 메시지를 만들지 않는 데 사용합니다.
+func (h *Hub[T]) Subscribers(topic string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.topics[topic])
+}
+
+// Close 함수는 모든 구독의 C를 닫아 구독자 고루틴이 끝나게 합니다. 이후 구독은 바로 닫힙니다.
+func (h *Hub[T]) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		return
+	}
+	h.closed = true
+	for _, subs := range h.topics {
+		for sub := range subs {
+			close(sub.ch)
+		}
+	}
+	h.topics = map[string]map[*Subscription[T]]struct{}{}
+}
+
+// C 함수는 메시지를 받는 채널을 반환합니다. 구독이나 허브가 닫히면 채널도 닫힙니다.
+func (s *Subscription[T]) C() <-chan T {
+	return s.ch
+}
+
+// Close 함수는 구독을 해지합니다. 여러 번 호출해도 안전합니다.
+func (s *Subscription[T]) Close() {
+	h := s.hub
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subs := h.topics[s.topic]
+	if _, ok := subs[s]; !ok {
+		return // 이미 해지했거나 허브가 닫힘
+	}
+	delete(subs, s)
+	if len(subs) == 0 {
+		delete(h.topics, s.topic)
+	}
+	close(s.ch)
+}
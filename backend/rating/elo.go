@@ -0,0 +1,28 @@
+// rating 패키지는 대전 결과로 갱신하는 게임별 Elo 레이팅과 레이팅 리더보드를 관리합니다.
+package rating
+
+import "math"
+
+// DefaultRating 대전 기록이 없는 사용자의 레이팅입니다.
+const DefaultRating = 1500
+
+// provisionalGames 이 판 수보다 적게 대전한 사용자는 레이팅이 빠르게 움직이도록 K 값을 크게 씁니다.
+const provisionalGames = 30
+
+// KFactor 함수는 대전 수에 따른 Elo K 값을 반환합니다.
+func KFactor(gamesPlayed int) float64 {
+	if gamesPlayed < provisionalGames {
+		return 40
+	}
+	return 20
+}
+
+// Expected 함수는 레이팅 a인 플레이어가 레이팅 b인 상대를 이길 기대 승률을 반환합니다.
+func Expected(a, b int) float64 {
+	return 1 / (1 + math.Pow(10, float64(b-a)/400))
+}
+
+// Update 함수는 한 플레이어의 대전 후 레이팅을 계산합니다. score는 승리 1, 무승부 0.5, 패배 0입니다.
+func Update(rating, opponent, gamesPlayed int, score float64) int {
+	return int(math.Round(float64(rating) + KFactor(gamesPlayed)*(score-Expected(rating, opponent))))
+}
@@ -0,0 +1,196 @@
+package rating
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"games/backend/db/models"
+)
+
+// Match 레이팅에 반영할 1대1 대전 결과입니다. WinnerID가 0이면 무승부입니다.
+type Match struct {
+	RoomID   string
+	Players  [2]int
+	WinnerID int
+	Reason   string
+	Duration time.Duration
+}
+
+// Service 레이팅 저장소(player_ratings, battle_matches)에 접근하는 서비스입니다.
+type Service struct {
+	db *sql.DB
+}
+
+// NewService 함수는 레이팅 서비스를 생성합니다.
+func NewService(db *sql.DB) *Service {
+	return &Service{db: db}
+}
+
+// Get 함수는 사용자의 레이팅과 전적을 반환합니다. 대전 기록이 없으면 기본 레이팅을 반환합니다.
+func (s *Service) Get(game string, userID int) (*models.PlayerRating, error) {
+	r := &models.PlayerRating{Game: game, UserID: userID, Rating: DefaultRating}
+	err := s.db.QueryRow(
+		`SELECT rating, wins, losses, draws, games_played, updated_at
+		FROM player_ratings
+		WHERE game = $1 AND user_id = $2`,
+		game, userID,
+	).Scan(&r.Rating, &r.Wins, &r.Losses, &r.Draws, &r.GamesPlayed, &r.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return r, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("레이팅 조회 실패: %v", err)
+	}
+	return r, nil
+}
+
+// Record 함수는 대전 결과로 두 플레이어의 레이팅과 전적을 갱신하고 대전 기록을 저장합니다.
+// 사용자 ID별 새 레이팅을 반환합니다.
+func (s *Service) Record(game string, match Match) (map[int]int, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("트랜잭션 시작 실패: %v", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	a, b := match.Players[0], match.Players[1]
+
+	// 첫 대전이면 기본 레이팅으로 행을 만든 뒤, 교착을 피하기 위해 사용자 ID 순서로 잠금
+	_, err = tx.Exec(
+		`INSERT INTO player_ratings (game, user_id, rating, updated_at)
+		VALUES ($1, $2, $4, $5), ($1, $3, $4, $5)
+		ON CONFLICT (game, user_id) DO NOTHING`,
+		game, a, b, DefaultRating, now,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("레이팅 생성 실패: %v", err)
+	}
+
+	rows, err := tx.Query(
+		`SELECT user_id, rating, games_played FROM player_ratings
+		WHERE game = $1 AND user_id IN ($2, $3)
+		ORDER BY user_id
+		FOR UPDATE`,
+		game, a, b,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("레이팅 조회 실패: %v", err)
+	}
+	ratings := map[int]int{}
+	played := map[int]int{}
+	for rows.Next() {
+		var userID, r, games int
+		if err := rows.Scan(&userID, &r, &games); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("레이팅 데이터 처리 실패: %v", err)
+		}
+		ratings[userID] = r
+		played[userID] = games
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("레이팅 조회 실패: %v", err)
+	}
+
+	updated := map[int]int{}
+	for i, userID := range match.Players {
+		opponent := match.Players[1-i]
+
+		score, wins, losses, draws := 0.5, 0, 0, 1
+		switch match.WinnerID {
+		case userID:
+			score, wins, draws = 1, 1, 0
+		case opponent:
+			score, losses, draws = 0, 1, 0
+		}
+		updated[userID] = Update(ratings[userID], ratings[opponent], played[userID], score)
+
+		_, err = tx.Exec(
+			`UPDATE player_ratings
+			SET rating = $1, wins = wins + $2, losses = losses + $3, draws = draws + $4,
+				games_played = games_played + 1, updated_at = $5
+			WHERE game = $6 AND user_id = $7`,
+			updated[userID], wins, losses, draws, now, game, userID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("레이팅 갱신 실패: %v", err)
+		}
+	}
+
+	var winnerID sql.NullInt64
+	if match.WinnerID != 0 {
+		winnerID = sql.NullInt64{Int64: int64(match.WinnerID), Valid: true}
+	}
+	_, err = tx.Exec(
+		`INSERT INTO battle_matches
+			(game, room_id, player1_id, player2_id, winner_id, reason, duration_ms,
+			player1_rating_before, player1_rating_after, player2_rating_before, player2_rating_after, played_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
+		game, match.RoomID, a, b, winnerID, match.Reason, match.Duration.Milliseconds(),
+		ratings[a], updated[a], ratings[b], updated[b], now,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("대전 기록 저장 실패: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("레이팅 커밋 실패: %v", err)
+	}
+	return updated, nil
+}
+
+// Leaderboard 함수는 대전을 한 번 이상 한 사용자의 레이팅 순위 목록과 전체 사용자 수를 반환합니다.
+// 같은 레이팅은 같은 순위(1, 2, 2, 4)이며, 먼저 그 레이팅에 도달한 사용자가 위에 옵니다.
+func (s *Service) Leaderboard(game string, limit, offset int) ([]models.PlayerRating, int, error) {
+	rows, err := s.db.Query(
+		`SELECT
+			RANK() OVER (ORDER BY pr.rating DESC),
+			pr.user_id, u.username, u.nickname,
+			pr.rating, pr.wins, pr.losses, pr.draws, pr.games_played, pr.updated_at
+		FROM player_ratings pr
+		JOIN users u ON u.id = pr.user_id
+		WHERE pr.game = $1 AND pr.games_played > 0
+		ORDER BY pr.rating DESC, pr.updated_at ASC, pr.user_id ASC
+		LIMIT $2 OFFSET $3`,
+		game, limit, offset,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("레이팅 리더보드 조회 실패: %v", err)
+	}
+	defer rows.Close()
+
+	leaderboard := []models.PlayerRating{}
+	for rows.Next() {
+		entry := models.PlayerRating{Game: game}
+		if err := rows.Scan(
+			&entry.Rank,
+			&entry.UserID,
+			&entry.Username,
+			&entry.Nickname,
+			&entry.Rating,
+			&entry.Wins,
+			&entry.Losses,
+			&entry.Draws,
+			&entry.GamesPlayed,
+			&entry.UpdatedAt,
+		); err != nil {
+			return nil, 0, fmt.Errorf("레이팅 리더보드 데이터 처리 실패: %v", err)
+		}
+		leaderboard = append(leaderboard, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("레이팅 리더보드 조회 실패: %v", err)
+	}
+
+	var total int
+	err = s.db.QueryRow(
+		`SELECT COUNT(*) FROM player_ratings WHERE game = $1 AND games_played > 0`,
+		game,
+	).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("전체 레이팅 수 조회 실패: %v", err)
+	}
+	return leaderboard, total, nil
+}
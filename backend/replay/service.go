@@ -0,0 +1,81 @@
+// replay 패키지는 검증을 통과한 게임의 압축 리플레이를 저장하고 조회합니다.
+// 리플레이 형식(압축/해제)은 게임 엔진 패키지가 담당하고, 이 패키지는 플레이 기록별로 바이트를 보관합니다.
+package replay
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"games/backend/db/models"
+)
+
+// MaxSize 저장할 수 있는 압축 리플레이의 최대 크기입니다.
+const MaxSize = 1 << 20
+
+var (
+	// ErrNotFound 리플레이가 없을 때 반환됩니다.
+	ErrNotFound = errors.New("리플레이를 찾을 수 없습니다")
+	// ErrTooLarge 압축한 리플레이가 MaxSize보다 클 때 반환됩니다.
+	ErrTooLarge = errors.New("리플레이가 너무 큽니다")
+)
+
+// Service 리플레이 저장소(game_replays)에 접근하는 서비스입니다.
+type Service struct {
+	db *sql.DB
+}
+
+// NewService 함수는 리플레이 서비스를 생성합니다.
+func NewService(db *sql.DB) *Service {
+	return &Service{db: db}
+}
+
+// Save 함수는 플레이 기록의 압축 리플레이를 저장합니다. size는 압축 전 크기입니다.
+func (s *Service) Save(playID int64, game string, userID, version int, data []byte, size int) error {
+	if len(data) > MaxSize {
+		return ErrTooLarge
+	}
+
+	_, err := s.db.Exec(
+		`INSERT INTO game_replays (play_id, game, user_id, version, data, size_bytes, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		playID, game, userID, version, data, size, time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("리플레이 저장 실패: %v", err)
+	}
+	return nil
+}
+
+// Get 함수는 게임의 플레이 기록 리플레이를 플레이 정보와 함께 반환합니다.
+func (s *Service) Get(game string, playID int64) (*models.GameReplay, error) {
+	replay := &models.GameReplay{PlayID: playID, Game: game}
+	err := s.db.QueryRow(
+		`SELECT gp.user_id, u.username, u.nickname, gp.score, gp.lines, gp.level, gp.duration_ms, gp.played_at,
+			gr.version, gr.data
+		FROM game_replays gr
+		JOIN game_plays gp ON gp.id = gr.play_id
+		JOIN users u ON u.id = gp.user_id
+		WHERE gr.play_id = $1 AND gr.game = $2`,
+		playID, game,
+	).Scan(
+		&replay.UserID,
+		&replay.Username,
+		&replay.Nickname,
+		&replay.Score,
+		&replay.Lines,
+		&replay.Level,
+		&replay.DurationMs,
+		&replay.PlayedAt,
+		&replay.Version,
+		&replay.Data,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("리플레이 조회 실패: %v", err)
+	}
+	return replay, nil
+}
@@ -0,0 +1,125 @@
+// revocation 패키지는 만료 전에 폐기한 액세스 토큰(JWT)의 ID(jti)를 관리합니다.
+// 폐기 목록은 DB에 저장해 여러 서버가 공유하고, 요청마다 DB를 조회하지 않도록 메모리에 캐시합니다.
+package revocation
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// negativeTTL 폐기되지 않은 토큰이라는 조회 결과를 캐시하는 시간입니다.
+// 다른 서버에서 폐기한 토큰은 최대 이 시간 동안 이 서버에서 계속 허용될 수 있습니다.
+const negativeTTL = 30 * time.Second
+
+// entry 캐시 항목입니다. 폐기된 토큰은 토큰 만료 시각까지, 폐기되지 않은 토큰은 negativeTTL 동안 보관합니다.
+type entry struct {
+	revoked bool
+	until   time.Time
+}
+
+// Store 폐기된 토큰 저장소(revoked_tokens)와 그 캐시입니다.
+type Store struct {
+	db *sql.DB
+
+	mu    sync.Mutex
+	cache map[string]entry
+}
+
+// NewStore 함수는 토큰 폐기 저장소를 생성합니다.
+func NewStore(db *sql.DB) *Store {
+	return &Store{db: db, cache: map[string]entry{}}
+}
+
+// Revoke 함수는 jti 토큰을 폐기합니다. expiresAt은 토큰 자체의 만료 시각이며, 이후에는 기록을 지워도 됩니다.
+func (s *Store) Revoke(jti string, userID int, expiresAt time.Time) error {
+	_, err := s.db.Exec(
+		`INSERT INTO revoked_tokens (jti, user_id, expires_at, revoked_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (jti) DO NOTHING`,
+		jti, userID, expiresAt, time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("토큰 폐기 저장 실패: %v", err)
+	}
+
+	s.mu.Lock()
+	s.cache[jti] = entry{revoked: true, until: expiresAt}
+	s.mu.Unlock()
+	return nil
+}
+
+// IsRevoked 함수는 jti 토큰이 폐기되었는지 반환합니다. 캐시에 없으면 DB를 조회해 결과를 캐시합니다.
+// expiresAt은 토큰 자체의 만료 시각이며, 폐기된 토큰은 그때까지 캐시합니다.
+func (s *Store) IsRevoked(jti string, expiresAt time.Time) (bool, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	cached, ok := s.cache[jti]
+	s.mu.Unlock()
+	if ok && now.Before(cached.until) {
+		return cached.revoked, nil
+	}
+
+	var revoked bool
+	err := s.db.QueryRow(`SELECT EXISTS(SELECT 1 FROM revoked_tokens WHERE jti = $1)`, jti).Scan(&revoked)
+	if err != nil {
+		return false, fmt.Errorf("토큰 폐기 여부 조회 실패: %v", err)
+	}
+
+	until := now.Add(negativeTTL)
+	if revoked {
+		until = expiresAt
+	}
+	s.mu.Lock()
+	s.cache[jti] = entry{revoked: revoked, until: until}
+	s.mu.Unlock()
+	return revoked, nil
+}
+
+// Prune 함수는 만료된 토큰의 폐기 기록과 오래된 캐시 항목을 지우고, 지운 기록 수를 반환합니다.
+// 만료된 토큰은 서명 검증 단계에서 거절되므로 폐기 기록이 필요 없습니다.
+func (s *Store) Prune() (int64, error) {
+	now := time.Now()
+
+	s.mu.Lock()
+	for jti, cached := range s.cache {
+		if !now.Before(cached.until) {
+			delete(s.cache, jti)
+		}
+	}
+	s.mu.Unlock()
+
+	result, err := s.db.Exec(`DELETE FROM revoked_tokens WHERE expires_at <= $1::timestamp`, now)
+	if err != nil {
+		return 0, fmt.Errorf("만료된 토큰 폐기 기록 삭제 실패: %v", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("처리 결과 확인 실패: %v", err)
+	}
+	return deleted, nil
+}
+
+// Run 함수는 interval마다 만료된 폐기 기록을 정리합니다. ctx가 취소될 때까지 실행되므로 고루틴으로 호출합니다.
+func (s *Store) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if deleted, err := s.Prune(); err != nil {
+			log.Printf("토큰 폐기 기록 정리 실패: %v", err)
+		} else if deleted > 0 {
+			log.Printf("만료된 토큰 폐기 기록 %d개를 삭제했습니다", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
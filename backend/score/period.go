@@ -0,0 +1,48 @@
+package score
+
+import (
+	"fmt"
+	"time"
+)
+
+// Period 리더보드 집계 기간입니다.
+type Period string
+
+const (
+	PeriodAll     Period = "all"     // 전체 기간 (game_scores의 최고 점수)
+	PeriodDaily   Period = "daily"   // 오늘 00:00부터
+	PeriodWeekly  Period = "weekly"  // 이번 주 월요일 00:00부터
+	PeriodMonthly Period = "monthly" // 이번 달 1일 00:00부터
+)
+
+// ParsePeriod 함수는 쿼리 파라미터 값을 Period로 변환합니다. 빈 값은 전체 기간입니다.
+func ParsePeriod(value string) (Period, error) {
+	switch Period(value) {
+	case "", PeriodAll:
+		return PeriodAll, nil
+	case PeriodDaily, PeriodWeekly, PeriodMonthly:
+		return Period(value), nil
+	}
+	return "", fmt.Errorf("알 수 없는 기간입니다: %q (daily, weekly, monthly, all 중 하나)", value)
+}
+
+// Range 함수는 now가 속한 기간의 [시작, 끝) 시각을 loc 시간대 기준으로 계산합니다.
+// 전체 기간이면 두 값 모두 zero time입니다.
+func (p Period) Range(now time.Time, loc *time.Location) (time.Time, time.Time) {
+	now = now.In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	switch p {
+	case PeriodDaily:
+		return today, today.AddDate(0, 0, 1)
+	case PeriodWeekly:
+		// 월요일 시작 (time.Weekday는 일요일이 0)
+		offset := (int(today.Weekday()) + 6) % 7
+		start := today.AddDate(0, 0, -offset)
+		return start, start.AddDate(0, 0, 7)
+	case PeriodMonthly:
+		start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc)
+		return start, start.AddDate(0, 1, 0)
+	}
+	return time.Time{}, time.Time{}
+}
@@ -0,0 +1,104 @@
+package score
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPeriodRange(t *testing.T) {
+	seoul, err := time.LoadLocation("Asia/Seoul")
+	if err != nil {
+		t.Fatal(err)
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name       string
+		period     Period
+		now        time.Time
+		loc        *time.Location
+		start, end time.Time
+	}{
+		{
+			// UTC로는 15일이지만 KST로는 16일 (토요일)
+			name: "daily KST", period: PeriodDaily,
+			now: time.Date(2024, 3, 15, 23, 30, 0, 0, time.UTC), loc: seoul,
+			start: time.Date(2024, 3, 16, 0, 0, 0, 0, seoul), end: time.Date(2024, 3, 17, 0, 0, 0, 0, seoul),
+		},
+		{
+			name: "daily UTC", period: PeriodDaily,
+			now: time.Date(2024, 3, 15, 23, 30, 0, 0, time.UTC), loc: time.UTC,
+			start: time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), end: time.Date(2024, 3, 16, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			// 서머타임 시작일은 23시간
+			name: "daily DST", period: PeriodDaily,
+			now: time.Date(2024, 3, 10, 12, 0, 0, 0, newYork), loc: newYork,
+			start: time.Date(2024, 3, 10, 0, 0, 0, 0, newYork), end: time.Date(2024, 3, 11, 0, 0, 0, 0, newYork),
+		},
+		{
+			name: "weekly saturday", period: PeriodWeekly,
+			now: time.Date(2024, 3, 16, 8, 0, 0, 0, seoul), loc: seoul,
+			start: time.Date(2024, 3, 11, 0, 0, 0, 0, seoul), end: time.Date(2024, 3, 18, 0, 0, 0, 0, seoul),
+		},
+		{
+			// 일요일은 그 주의 마지막 날
+			name: "weekly sunday", period: PeriodWeekly,
+			now: time.Date(2024, 3, 17, 23, 59, 59, 0, seoul), loc: seoul,
+			start: time.Date(2024, 3, 11, 0, 0, 0, 0, seoul), end: time.Date(2024, 3, 18, 0, 0, 0, 0, seoul),
+		},
+		{
+			name: "weekly monday midnight", period: PeriodWeekly,
+			now: time.Date(2024, 3, 18, 0, 0, 0, 0, seoul), loc: seoul,
+			start: time.Date(2024, 3, 18, 0, 0, 0, 0, seoul), end: time.Date(2024, 3, 25, 0, 0, 0, 0, seoul),
+		},
+		{
+			name: "weekly across month", period: PeriodWeekly,
+			now: time.Date(2024, 3, 1, 12, 0, 0, 0, seoul), loc: seoul,
+			start: time.Date(2024, 2, 26, 0, 0, 0, 0, seoul), end: time.Date(2024, 3, 4, 0, 0, 0, 0, seoul),
+		},
+		{
+			name: "monthly leap year", period: PeriodMonthly,
+			now: time.Date(2024, 2, 29, 18, 0, 0, 0, seoul), loc: seoul,
+			start: time.Date(2024, 2, 1, 0, 0, 0, 0, seoul), end: time.Date(2024, 3, 1, 0, 0, 0, 0, seoul),
+		},
+		{
+			// UTC로는 12월 31일이지만 KST로는 새해
+			name: "monthly new year KST", period: PeriodMonthly,
+			now: time.Date(2024, 12, 31, 16, 0, 0, 0, time.UTC), loc: seoul,
+			start: time.Date(2025, 1, 1, 0, 0, 0, 0, seoul), end: time.Date(2025, 2, 1, 0, 0, 0, 0, seoul),
+		},
+		{
+			name: "monthly december", period: PeriodMonthly,
+			now: time.Date(2024, 12, 31, 16, 0, 0, 0, time.UTC), loc: time.UTC,
+			start: time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC), end: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "all", period: PeriodAll,
+			now: time.Date(2024, 3, 15, 12, 0, 0, 0, seoul), loc: seoul,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			start, end := tc.period.Range(tc.now, tc.loc)
+			if !start.Equal(tc.start) || !end.Equal(tc.end) {
+				t.Fatalf("Range = [%v, %v), 기대값 [%v, %v)", start, end, tc.start, tc.end)
+			}
+		})
+	}
+}
+
+func TestParsePeriod(t *testing.T) {
+	cases := map[string]Period{"": PeriodAll, "all": PeriodAll, "daily": PeriodDaily, "weekly": PeriodWeekly, "monthly": PeriodMonthly}
+	for value, want := range cases {
+		if got, err := ParsePeriod(value); err != nil || got != want {
+			t.Errorf("ParsePeriod(%q) = %q, %v, 기대값 %q", value, got, err, want)
+		}
+	}
+	if _, err := ParsePeriod("yearly"); err == nil {
+		t.Error(`ParsePeriod("yearly")가 오류를 반환하지 않았습니다`)
+	}
+}
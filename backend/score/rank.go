@@ -0,0 +1,33 @@
+package score
+
+import "fmt"
+
+// RankMode 동점자의 순위를 매기는 방식입니다.
+// 어느 방식이든 동점자는 같은 순위이고, 목록에서는 그 점수를 먼저 달성한(updated_at이 이른) 기록이 위에 표시됩니다.
+type RankMode string
+
+const (
+	// RankCompetition 표준 경쟁 순위 (1, 2, 2, 4): 동점자 수만큼 다음 순위를 건너뜁니다.
+	RankCompetition RankMode = "competition"
+	// RankDense 밀집 순위 (1, 2, 2, 3): 동점자 다음 순위를 건너뛰지 않습니다.
+	RankDense RankMode = "dense"
+)
+
+// ParseRankMode 함수는 설정/쿼리 파라미터 값을 RankMode로 변환합니다. 빈 값은 표준 경쟁 순위입니다.
+func ParseRankMode(value string) (RankMode, error) {
+	switch RankMode(value) {
+	case "", RankCompetition:
+		return RankCompetition, nil
+	case RankDense:
+		return RankDense, nil
+	}
+	return "", fmt.Errorf("알 수 없는 순위 방식입니다: %q (competition, dense 중 하나)", value)
+}
+
+// windowSQL 함수는 순위 방식에 맞는 SQL 윈도 함수 이름을 반환합니다.
+func (m RankMode) windowSQL() string {
+	if m == RankDense {
+		return "DENSE_RANK()"
+	}
+	return "RANK()"
+}
@@ -0,0 +1,530 @@
+// score 패키지는 게임 구분 없이 사용하는 점수 저장/조회 서비스를 정의합니다.
+package score
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"games/backend/db/models"
+	"games/backend/game"
+)
+
+// ErrNoRecord 사용자의 최고 점수 기록이 없을 때 반환됩니다.
+var ErrNoRecord = errors.New("점수 기록이 없습니다")
+
+// Result 끝난 게임 한 판의 결과입니다. 게임에서 사용하지 않는 값은 0으로 둡니다.
+type Result struct {
+	Score           int
+	Lines           int
+	Level           int
+	DurationMs      int
+	PiecesPlaced    int
+	MaxCombo        int
+	GarbageSurvived int
+	Stats           map[string]int // 고정 컬럼에 없는 게임별 점수 필드
+}
+
+// ResultFromFields 함수는 game.Game.ParseScore로 검증한 점수 필드를 Result로 변환합니다.
+// 고정 컬럼에 해당하지 않는 필드는 Stats에 담깁니다.
+func ResultFromFields(fields map[string]int) Result {
+	var result Result
+	for name, value := range fields {
+		switch name {
+		case "score":
+			result.Score = value
+		case "lines":
+			result.Lines = value
+		case "level":
+			result.Level = value
+		case "duration_ms":
+			result.DurationMs = value
+		case "pieces_placed":
+			result.PiecesPlaced = value
+		case "max_combo":
+			result.MaxCombo = value
+		case "garbage_survived":
+			result.GarbageSurvived = value
+		default:
+			if result.Stats == nil {
+				result.Stats = make(map[string]int)
+			}
+			result.Stats[name] = value
+		}
+	}
+	return result
+}
+
+// Submission 점수 제출 처리 결과입니다.
+type Submission struct {
+	PlayID    int64 // 저장된 플레이 기록 ID
+	IsNewBest bool  // 최고 점수 갱신 여부
+	BestScore int   // 처리 후의 최고 점수
+}
+
+// HistoryQuery 플레이 기록 조회 조건입니다. 시각 필드는 Valid일 때만 적용됩니다.
+type HistoryQuery struct {
+	Limit    int
+	From     sql.NullTime // 포함
+	To       sql.NullTime // 미포함
+	Before   sql.NullTime // 커서: 이 시각/ID 이전 기록만 조회
+	BeforeID int64
+}
+
+// Service 점수 저장소(game_scores, game_plays)에 접근하는 서비스입니다.
+// 게임별 점수 정렬 방향(높을수록/낮을수록 좋음)은 게임 레지스트리에서 가져옵니다.
+type Service struct {
+	db    *sql.DB
+	games *game.Registry
+}
+
+// NewService 함수는 점수 서비스를 생성합니다.
+func NewService(db *sql.DB, games *game.Registry) *Service {
+	return &Service{db: db, games: games}
+}
+
+// gameInfo 함수는 게임 정의를 반환합니다. 등록되지 않은 게임은 높을수록 좋은 점수로 취급합니다.
+func (s *Service) gameInfo(slug string) game.Game {
+	if g, ok := s.games.Get(slug); ok {
+		return g
+	}
+	return game.Game{Slug: slug, ScoreOrder: game.OrderDesc}
+}
+
+// orderSQL 함수는 게임의 점수 정렬 방향을 SQL 정렬 키워드로 반환합니다.
+func orderSQL(g game.Game) string {
+	if g.ScoreOrder == game.OrderAsc {
+		return "ASC"
+	}
+	return "DESC"
+}
+
+// betterSQL 함수는 "더 좋은 점수" 비교 연산자를 반환합니다.
+func betterSQL(g game.Game) string {
+	if g.ScoreOrder == game.OrderAsc {
+		return "<"
+	}
+	return ">"
+}
+
+// encodeStats 함수는 게임별 추가 필드를 JSONB 컬럼에 저장할 문자열로 변환합니다.
+func encodeStats(stats map[string]int) (string, error) {
+	if len(stats) == 0 {
+		return "{}", nil
+	}
+	encoded, err := json.Marshal(stats)
+	return string(encoded), err
+}
+
+// decodeStats 함수는 JSONB 컬럼 값을 게임별 추가 필드로 변환합니다. 비어 있으면 nil을 반환합니다.
+func decodeStats(raw []byte) (map[string]int, error) {
+	var stats map[string]int
+	if err := json.Unmarshal(raw, &stats); err != nil {
+		return nil, err
+	}
+	if len(stats) == 0 {
+		return nil, nil
+	}
+	return stats, nil
+}
+
+// Submit 함수는 한 판의 결과를 플레이 기록으로 저장하고, 게임의 정렬 방향 기준으로 최고 점수보다 좋으면 갱신합니다.
+func (s *Service) Submit(slug string, userID int, result Result) (*Submission, error) {
+	stats, err := encodeStats(result.Stats)
+	if err != nil {
+		return nil, fmt.Errorf("추가 점수 필드 변환 실패: %v", err)
+	}
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("트랜잭션 시작 실패: %v", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	submission := &Submission{}
+
+	// 최고 점수 여부와 관계없이 플레이 기록 저장
+	err = tx.QueryRow(
+		`INSERT INTO game_plays
+		(game, user_id, score, lines, level, duration_ms, pieces_placed, max_combo, garbage_survived, stats, played_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id`,
+		slug, userID, result.Score, result.Lines, result.Level,
+		result.DurationMs, result.PiecesPlaced, result.MaxCombo, result.GarbageSurvived, stats, now,
+	).Scan(&submission.PlayID)
+	if err != nil {
+		return nil, fmt.Errorf("플레이 기록 저장 실패: %v", err)
+	}
+
+	// 현재 최고 점수 조회 (동시 제출 시 갱신이 엇갈리지 않도록 잠금)
+	var currentScore int
+	err = tx.QueryRow(
+		`SELECT score FROM game_scores WHERE game = $1 AND user_id = $2 FOR UPDATE`,
+		slug, userID,
+	).Scan(&currentScore)
+
+	switch {
+	case err == sql.ErrNoRows:
+		// 첫 기록인 경우 INSERT
+		_, err = tx.Exec(
+			`INSERT INTO game_scores
+			(game, user_id, score, lines, level, stats, best_play_id, created_at, updated_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+			slug, userID, result.Score, result.Lines, result.Level, stats, submission.PlayID, now, now,
+		)
+		submission.IsNewBest = true
+	case err != nil:
+		return nil, fmt.Errorf("최고 점수 조회 실패: %v", err)
+	case s.gameInfo(slug).Better(result.Score, currentScore):
+		// 기존 기록보다 좋은 경우 UPDATE
+		_, err = tx.Exec(
+			`UPDATE game_scores
+			SET score = $1, lines = $2, level = $3, stats = $4, best_play_id = $5, updated_at = $6
+			WHERE game = $7 AND user_id = $8`,
+			result.Score, result.Lines, result.Level, stats, submission.PlayID, now, slug, userID,
+		)
+		submission.IsNewBest = true
+	}
+	if err != nil {
+		return nil, fmt.Errorf("최고 점수 업데이트 실패: %v", err)
+	}
+
+	if submission.IsNewBest {
+		submission.BestScore = result.Score
+	} else {
+		submission.BestScore = currentScore
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("점수 저장 커밋 실패: %v", err)
+	}
+	return submission, nil
+}
+
+// Best 함수는 사용자의 최고 점수를 사용자 정보와 함께 반환합니다. 기록이 없으면 ErrNoRecord를 반환합니다.
+func (s *Service) Best(slug string, userID int) (*models.GameScore, error) {
+	best := &models.GameScore{Game: slug, UserID: userID}
+	var stats []byte
+	err := s.db.QueryRow(
+		`SELECT u.username, u.nickname, gs.score, gs.lines, gs.level, gs.stats, gs.created_at, gs.updated_at
+		FROM game_scores gs
+		JOIN users u ON gs.user_id = u.id
+		WHERE gs.game = $1 AND gs.user_id = $2`,
+		slug, userID,
+	).Scan(
+		&best.Username,
+		&best.Nickname,
+		&best.Score,
+		&best.Lines,
+		&best.Level,
+		&stats,
+		&best.CreatedAt,
+		&best.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, ErrNoRecord
+	}
+	if err != nil {
+		return nil, fmt.Errorf("최고 점수 조회 실패: %v", err)
+	}
+	if best.Stats, err = decodeStats(stats); err != nil {
+		return nil, fmt.Errorf("추가 점수 필드 처리 실패: %v", err)
+	}
+	return best, nil
+}
+
+// Rank 함수는 해당 게임의 전체 기간 리더보드에서 score 점수의 순위를 mode 방식으로 반환합니다.
+// Leaderboard의 rank 값과 같은 규칙입니다.
+func (s *Service) Rank(slug string, score int, mode RankMode) (int, error) {
+	count := "COUNT(*)"
+	if mode == RankDense {
+		count = "COUNT(DISTINCT score)"
+	}
+
+	var rank int
+	err := s.db.QueryRow(
+		`SELECT `+count+` + 1
+		FROM game_scores
+		WHERE game = $1 AND score `+betterSQL(s.gameInfo(slug))+` $2`,
+		slug, score,
+	).Scan(&rank)
+	if err != nil {
+		return 0, fmt.Errorf("순위 조회 실패: %v", err)
+	}
+	return rank, nil
+}
+
+// LeaderboardQuery 리더보드 조회 조건입니다.
+// From/To가 zero time이면 전체 기간 최고 점수(game_scores)를, 아니면 [From, To) 사이 플레이 기록(game_plays)의 사용자별 최고 점수를 사용합니다.
+type LeaderboardQuery struct {
+	Limit  int
+	Offset int
+	From   time.Time
+	To     time.Time
+	Mode   RankMode // 동점자 순위 방식 (빈 값은 표준 경쟁 순위)
+	// UserIDs 비어 있지 않으면 이 사용자들의 기록만으로 순위를 매깁니다. (친구 리더보드)
+	UserIDs []int
+}
+
+// windowed 함수는 기간이 지정된 조회인지 반환합니다.
+func (q LeaderboardQuery) windowed() bool {
+	return !q.From.IsZero() && !q.To.IsZero()
+}
+
+// userFilter 함수는 UserIDs 조건의 SQL 파라미터 값을 반환합니다. 비어 있으면 NULL(전체 사용자)입니다.
+func (q LeaderboardQuery) userFilter() pq.Int64Array {
+	if len(q.UserIDs) == 0 {
+		return nil
+	}
+	ids := make(pq.Int64Array, len(q.UserIDs))
+	for i, id := range q.UserIDs {
+		ids[i] = int64(id)
+	}
+	return ids
+}
+
+// Leaderboard 함수는 게임의 정렬 방향에 따른 최고 점수 순위 목록과 전체 기록 수를 반환합니다.
+// 각 행의 Rank는 query.Mode 방식의 순위이며, 동점자는 그 점수를 먼저 달성한 기록이 위에 옵니다.
+func (s *Service) Leaderboard(slug string, query LeaderboardQuery) ([]models.GameScore, int, error) {
+	g := s.gameInfo(slug)
+
+	var rows *sql.Rows
+	var err error
+	if query.windowed() {
+		// 기간 내 사용자별 최고 기록 (같은 점수면 먼저 달성한 기록)
+		// TIMESTAMP 컬럼은 서버 로컬 시각으로 저장되므로 경계도 로컬 시각으로 변환해 비교합니다.
+		rows, err = s.db.Query(
+			`WITH best AS (
+				SELECT DISTINCT ON (gp.user_id)
+					gp.id, gp.user_id, gp.score, gp.lines, gp.level, gp.stats, gp.played_at
+				FROM game_plays gp
+				WHERE gp.game = $1 AND gp.played_at >= $2 AND gp.played_at < $3
+					AND ($6::bigint[] IS NULL OR gp.user_id = ANY($6))
+				ORDER BY gp.user_id, gp.score `+orderSQL(g)+`, gp.played_at ASC
+			)
+			SELECT
+				`+query.Mode.windowSQL()+` OVER (ORDER BY b.score `+orderSQL(g)+`),
+				b.user_id,
+				u.username,
+				u.nickname,
+				b.score,
+				b.lines,
+				b.level,
+				b.stats,
+				b.played_at,
+				b.played_at,
+				b.id,
+				EXISTS (SELECT 1 FROM game_replays gr WHERE gr.play_id = b.id)
+			FROM best b
+			JOIN users u ON b.user_id = u.id
+			ORDER BY b.score `+orderSQL(g)+`, b.played_at ASC, b.user_id ASC
+			LIMIT $4 OFFSET $5`,
+			slug, query.From.In(time.Local), query.To.In(time.Local), query.Limit, query.Offset, query.userFilter(),
+		)
+	} else {
+		rows, err = s.db.Query(
+			`SELECT
+				`+query.Mode.windowSQL()+` OVER (ORDER BY gs.score `+orderSQL(g)+`),
+				gs.user_id,
+				u.username,
+				u.nickname,
+				gs.score,
+				gs.lines,
+				gs.level,
+				gs.stats,
+				gs.created_at,
+				gs.updated_at,
+				COALESCE(gs.best_play_id, 0),
+				EXISTS (SELECT 1 FROM game_replays gr WHERE gr.play_id = gs.best_play_id)
+			FROM game_scores gs
+			JOIN users u ON gs.user_id = u.id
+			WHERE gs.game = $1 AND ($4::bigint[] IS NULL OR gs.user_id = ANY($4))
+			ORDER BY gs.score `+orderSQL(g)+`, gs.updated_at ASC, gs.user_id ASC
+			LIMIT $2 OFFSET $3`,
+			slug, query.Limit, query.Offset, query.userFilter(),
+		)
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("리더보드 조회 실패: %v", err)
+	}
+	defer rows.Close()
+
+	leaderboard := []models.GameScore{}
+	for rows.Next() {
+		entry := models.GameScore{Game: slug}
+		var stats []byte
+		if err := rows.Scan(
+			&entry.Rank,
+			&entry.UserID,
+			&entry.Username,
+			&entry.Nickname,
+			&entry.Score,
+			&entry.Lines,
+			&entry.Level,
+			&stats,
+			&entry.CreatedAt,
+			&entry.UpdatedAt,
+			&entry.PlayID,
+			&entry.HasReplay,
+		); err != nil {
+			return nil, 0, fmt.Errorf("리더보드 데이터 처리 실패: %v", err)
+		}
+		if entry.Stats, err = decodeStats(stats); err != nil {
+			return nil, 0, fmt.Errorf("추가 점수 필드 처리 실패: %v", err)
+		}
+		leaderboard = append(leaderboard, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("리더보드 조회 실패: %v", err)
+	}
+
+	// 전체 레코드 수 조회 (페이지네이션 정보용)
+	var total int
+	if query.windowed() {
+		err = s.db.QueryRow(
+			`SELECT COUNT(DISTINCT user_id) FROM game_plays
+			WHERE game = $1 AND played_at >= $2 AND played_at < $3
+				AND ($4::bigint[] IS NULL OR user_id = ANY($4))`,
+			slug, query.From.In(time.Local), query.To.In(time.Local), query.userFilter(),
+		).Scan(&total)
+	} else {
+		err = s.db.QueryRow(
+			`SELECT COUNT(*) FROM game_scores
+			WHERE game = $1 AND ($2::bigint[] IS NULL OR user_id = ANY($2))`,
+			slug, query.userFilter(),
+		).Scan(&total)
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("전체 기록 수 조회 실패: %v", err)
+	}
+
+	return leaderboard, total, nil
+}
+
+// Around 함수는 전체 기간 리더보드에서 사용자의 위아래 radius명씩을 사용자 본인과 함께 순위 순서로 반환합니다.
+// 목록 위치는 Leaderboard와 같은 정렬(점수, 먼저 달성한 기록, 사용자 ID)을 따르고, Rank는 mode 방식의 순위입니다.
+// 사용자의 기록이 없으면 ErrNoRecord를 반환합니다.
+func (s *Service) Around(slug string, userID, radius int, mode RankMode) ([]models.GameScore, error) {
+	g := s.gameInfo(slug)
+
+	rows, err := s.db.Query(
+		`WITH ranked AS (
+			SELECT
+				`+mode.windowSQL()+` OVER (ORDER BY gs.score `+orderSQL(g)+`) AS rank,
+				ROW_NUMBER() OVER (ORDER BY gs.score `+orderSQL(g)+`, gs.updated_at ASC, gs.user_id ASC) AS position,
+				gs.user_id, gs.score, gs.lines, gs.level, gs.stats, gs.created_at, gs.updated_at, gs.best_play_id
+			FROM game_scores gs
+			WHERE gs.game = $1
+		),
+		me AS (
+			SELECT position FROM ranked WHERE user_id = $2
+		)
+		SELECT
+			r.rank,
+			r.user_id,
+			u.username,
+			u.nickname,
+			r.score,
+			r.lines,
+			r.level,
+			r.stats,
+			r.created_at,
+			r.updated_at,
+			COALESCE(r.best_play_id, 0),
+			EXISTS (SELECT 1 FROM game_replays gr WHERE gr.play_id = r.best_play_id)
+		FROM ranked r
+		JOIN me ON r.position BETWEEN me.position - $3 AND me.position + $3
+		JOIN users u ON r.user_id = u.id
+		ORDER BY r.position ASC`,
+		slug, userID, radius,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("주변 순위 조회 실패: %v", err)
+	}
+	defer rows.Close()
+
+	around := []models.GameScore{}
+	for rows.Next() {
+		entry := models.GameScore{Game: slug}
+		var stats []byte
+		if err := rows.Scan(
+			&entry.Rank,
+			&entry.UserID,
+			&entry.Username,
+			&entry.Nickname,
+			&entry.Score,
+			&entry.Lines,
+			&entry.Level,
+			&stats,
+			&entry.CreatedAt,
+			&entry.UpdatedAt,
+			&entry.PlayID,
+			&entry.HasReplay,
+		); err != nil {
+			return nil, fmt.Errorf("주변 순위 데이터 처리 실패: %v", err)
+		}
+		if entry.Stats, err = decodeStats(stats); err != nil {
+			return nil, fmt.Errorf("추가 점수 필드 처리 실패: %v", err)
+		}
+		around = append(around, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("주변 순위 조회 실패: %v", err)
+	}
+
+	// 사용자 본인이 결과에 없으면 기록이 없는 것입니다.
+	if len(around) == 0 {
+		return nil, ErrNoRecord
+	}
+	return around, nil
+}
+
+// History 함수는 사용자의 플레이 기록을 최신순(played_at, id 내림차순)으로 반환합니다.
+func (s *Service) History(slug string, userID int, query HistoryQuery) ([]models.GamePlay, error) {
+	rows, err := s.db.Query(
+		`SELECT id, user_id, score, lines, level, duration_ms, pieces_placed, max_combo, garbage_survived, stats, played_at
+		FROM game_plays
+		WHERE game = $1 AND user_id = $2
+			AND ($3::timestamp IS NULL OR played_at >= $3)
+			AND ($4::timestamp IS NULL OR played_at < $4)
+			AND ($5::timestamp IS NULL OR (played_at, id) < ($5, $6))
+		ORDER BY played_at DESC, id DESC
+		LIMIT $7`,
+		slug, userID, query.From, query.To, query.Before, query.BeforeID, query.Limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("플레이 기록 조회 실패: %v", err)
+	}
+	defer rows.Close()
+
+	plays := []models.GamePlay{}
+	for rows.Next() {
+		play := models.GamePlay{Game: slug}
+		var stats []byte
+		if err := rows.Scan(
+			&play.ID,
+			&play.UserID,
+			&play.Score,
+			&play.Lines,
+			&play.Level,
+			&play.DurationMs,
+			&play.PiecesPlaced,
+			&play.MaxCombo,
+			&play.GarbageSurvived,
+			&stats,
+			&play.PlayedAt,
+		); err != nil {
+			return nil, fmt.Errorf("플레이 기록 처리 실패: %v", err)
+		}
+		if play.Stats, err = decodeStats(stats); err != nil {
+			return nil, fmt.Errorf("추가 점수 필드 처리 실패: %v", err)
+		}
+		plays = append(plays, play)
+	}
+	return plays, rows.Err()
+}
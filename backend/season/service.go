@@ -0,0 +1,355 @@
+// season 패키지는 게임별 시즌과 시즌 종료 시 최종 순위 보관을 담당합니다.
+package season
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"games/backend/db/models"
+	"games/backend/score"
+)
+
+var (
+	// ErrNotFound 시즌이 없을 때 반환됩니다.
+	ErrNotFound = errors.New("시즌을 찾을 수 없습니다")
+	// ErrOverlap 같은 게임의 다른 시즌과 기간이 겹칠 때 반환됩니다.
+	ErrOverlap = errors.New("같은 게임의 다른 시즌과 기간이 겹칩니다")
+	// ErrNotClosed 시즌 최종 순위가 아직 보관되지 않았을 때 반환됩니다.
+	ErrNotClosed = errors.New("시즌 최종 순위가 아직 확정되지 않았습니다")
+)
+
+const (
+	// DefaultTopN 시즌 생성 시 top_n을 지정하지 않으면 보관할 순위 수입니다.
+	DefaultTopN = 100
+	// MaxTopN 보관할 수 있는 최대 순위 수입니다.
+	MaxTopN = 1000
+)
+
+// Service 시즌 저장소(seasons, season_results)에 접근하는 서비스입니다.
+// 시즌 랭킹은 점수 서비스의 기간 리더보드(시즌 기간 내 game_plays)로 집계합니다.
+type Service struct {
+	db     *sql.DB
+	scores *score.Service
+	mode   score.RankMode // 최종 순위 보관 시 사용하는 순위 방식
+}
+
+// NewService 함수는 시즌 서비스를 생성합니다. mode는 시즌 종료 시 보관할 최종 순위의 동점자 처리 방식입니다.
+func NewService(db *sql.DB, scores *score.Service, mode score.RankMode) *Service {
+	return &Service{db: db, scores: scores, mode: mode}
+}
+
+// localTime 함수는 TIMESTAMP 컬럼에서 읽은 값(서버 로컬 벽시계 시각)을 서버 로컬 시간대의 시각으로 바꿉니다.
+func localTime(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.Local)
+}
+
+// status 함수는 now 기준 시즌 상태를 반환합니다.
+func status(season *models.Season, now time.Time) string {
+	switch {
+	case season.ClosedAt != nil:
+		return models.SeasonClosed
+	case now.Before(season.StartsAt):
+		return models.SeasonUpcoming
+	case now.Before(season.EndsAt):
+		return models.SeasonActive
+	}
+	return models.SeasonEnded
+}
+
+// seasonColumns 시즌 조회 시 사용하는 컬럼 목록입니다. (scanSeason과 순서가 같아야 합니다)
+const seasonColumns = `id, game, name, starts_at, ends_at, top_n, closed_at, created_at`
+
+// scanSeason 함수는 seasonColumns 순서의 행을 Season으로 변환합니다.
+func scanSeason(row interface{ Scan(...interface{}) error }) (*models.Season, error) {
+	season := &models.Season{}
+	var closedAt sql.NullTime
+	if err := row.Scan(
+		&season.ID,
+		&season.Game,
+		&season.Name,
+		&season.StartsAt,
+		&season.EndsAt,
+		&season.TopN,
+		&closedAt,
+		&season.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	season.StartsAt = localTime(season.StartsAt)
+	season.EndsAt = localTime(season.EndsAt)
+	season.CreatedAt = localTime(season.CreatedAt)
+	if closedAt.Valid {
+		t := localTime(closedAt.Time)
+		season.ClosedAt = &t
+	}
+	season.Status = status(season, time.Now())
+	return season, nil
+}
+
+// Create 함수는 새 시즌을 생성합니다. 같은 게임의 기존 시즌과 기간이 겹치면 ErrOverlap을 반환합니다.
+func (s *Service) Create(req models.SeasonRequest) (*models.Season, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("트랜잭션 시작 실패: %v", err)
+	}
+	defer tx.Rollback()
+
+	// 같은 게임의 시즌 생성을 직렬화해 기간 중복 검사가 엇갈리지 않도록 합니다.
+	if _, err := tx.Exec(`SELECT slug FROM games WHERE slug = $1 FOR UPDATE`, req.Game); err != nil {
+		return nil, fmt.Errorf("게임 잠금 실패: %v", err)
+	}
+
+	startsAt, endsAt := req.StartsAt.In(time.Local), req.EndsAt.In(time.Local)
+
+	var overlaps bool
+	err = tx.QueryRow(
+		`SELECT EXISTS(SELECT 1 FROM seasons WHERE game = $1 AND starts_at < $3 AND ends_at > $2)`,
+		req.Game, startsAt, endsAt,
+	).Scan(&overlaps)
+	if err != nil {
+		return nil, fmt.Errorf("시즌 기간 확인 실패: %v", err)
+	}
+	if overlaps {
+		return nil, ErrOverlap
+	}
+
+	season, err := scanSeason(tx.QueryRow(
+		`INSERT INTO seasons (game, name, starts_at, ends_at, top_n, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+seasonColumns,
+		req.Game, req.Name, startsAt, endsAt, req.TopN, time.Now(),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("시즌 생성 실패: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("시즌 생성 커밋 실패: %v", err)
+	}
+	return season, nil
+}
+
+// Get 함수는 ID에 해당하는 시즌을 반환합니다. 없으면 ErrNotFound를 반환합니다.
+func (s *Service) Get(id int) (*models.Season, error) {
+	season, err := scanSeason(s.db.QueryRow(`SELECT `+seasonColumns+` FROM seasons WHERE id = $1`, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("시즌 조회 실패: %v", err)
+	}
+	return season, nil
+}
+
+// List 함수는 시즌 목록을 최신 시즌부터 반환합니다. slug가 비어 있으면 모든 게임의 시즌을 반환합니다.
+func (s *Service) List(slug string) ([]models.Season, error) {
+	rows, err := s.db.Query(
+		`SELECT `+seasonColumns+`
+		FROM seasons
+		WHERE $1 = '' OR game = $1
+		ORDER BY starts_at DESC, id DESC`,
+		slug,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("시즌 목록 조회 실패: %v", err)
+	}
+	defer rows.Close()
+
+	seasons := []models.Season{}
+	for rows.Next() {
+		season, err := scanSeason(rows)
+		if err != nil {
+			return nil, fmt.Errorf("시즌 데이터 처리 실패: %v", err)
+		}
+		seasons = append(seasons, *season)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("시즌 목록 조회 실패: %v", err)
+	}
+	return seasons, nil
+}
+
+// Leaderboard 함수는 시즌 기간 동안의 사용자별 최고 기록 순위를 반환합니다. 진행 중인 시즌은 실시간 순위입니다.
+func (s *Service) Leaderboard(season *models.Season, limit, offset int, mode score.RankMode) ([]models.GameScore, int, error) {
+	return s.scores.Leaderboard(season.Game, score.LeaderboardQuery{
+		Limit:  limit,
+		Offset: offset,
+		From:   season.StartsAt,
+		To:     season.EndsAt,
+		Mode:   mode,
+	})
+}
+
+// Results 함수는 종료된 시즌의 보관된 최종 순위를 반환합니다. 아직 보관되지 않았으면 ErrNotClosed를 반환합니다.
+func (s *Service) Results(season *models.Season) ([]models.SeasonResult, error) {
+	if season.ClosedAt == nil {
+		return nil, ErrNotClosed
+	}
+
+	rows, err := s.db.Query(
+		`SELECT season_id, rank, user_id, username, nickname, score, lines, level, stats, achieved_at
+		FROM season_results
+		WHERE season_id = $1
+		ORDER BY rank ASC, achieved_at ASC`,
+		season.ID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("시즌 결과 조회 실패: %v", err)
+	}
+	defer rows.Close()
+
+	results := []models.SeasonResult{}
+	for rows.Next() {
+		var result models.SeasonResult
+		var stats []byte
+		if err := rows.Scan(
+			&result.SeasonID,
+			&result.Rank,
+			&result.UserID,
+			&result.Username,
+			&result.Nickname,
+			&result.Score,
+			&result.Lines,
+			&result.Level,
+			&stats,
+			&result.AchievedAt,
+		); err != nil {
+			return nil, fmt.Errorf("시즌 결과 데이터 처리 실패: %v", err)
+		}
+		if err := json.Unmarshal(stats, &result.Stats); err != nil {
+			return nil, fmt.Errorf("추가 점수 필드 처리 실패: %v", err)
+		}
+		if len(result.Stats) == 0 {
+			result.Stats = nil
+		}
+		result.AchievedAt = localTime(result.AchievedAt)
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("시즌 결과 조회 실패: %v", err)
+	}
+	return results, nil
+}
+
+// Close 함수는 종료 시각이 지난 시즌의 상위 top_n 순위를 season_results에 보관하고 시즌을 확정합니다.
+// 이미 확정되었거나, 아직 끝나지 않았거나, 다른 서버가 처리 중이면 false를 반환합니다.
+func (s *Service) Close(id int) (bool, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return false, fmt.Errorf("트랜잭션 시작 실패: %v", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+
+	// 여러 서버가 동시에 실행되어도 한 번만 보관되도록 시즌 행을 잠급니다.
+	season, err := scanSeason(tx.QueryRow(
+		`SELECT `+seasonColumns+`
+		FROM seasons
+		WHERE id = $1 AND closed_at IS NULL AND ends_at <= $2
+		FOR UPDATE SKIP LOCKED`,
+		id, now,
+	))
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("시즌 조회 실패: %v", err)
+	}
+
+	// 종료 시각 이후의 플레이는 시즌 기간에 포함되지 않으므로 순위는 더 이상 바뀌지 않습니다.
+	// top_n번째 순위와 동점인 기록은 잘릴 수 있으며, 보관되는 순위 값은 전체 순위 기준입니다.
+	standings, _, err := s.Leaderboard(season, season.TopN, 0, s.mode)
+	if err != nil {
+		return false, err
+	}
+
+	for _, entry := range standings {
+		stats, err := json.Marshal(entry.Stats)
+		if err != nil || entry.Stats == nil {
+			stats = []byte("{}")
+		}
+		_, err = tx.Exec(
+			`INSERT INTO season_results
+			(season_id, rank, user_id, username, nickname, score, lines, level, stats, achieved_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
+			season.ID, entry.Rank, entry.UserID, entry.Username, entry.Nickname,
+			entry.Score, entry.Lines, entry.Level, string(stats), entry.UpdatedAt,
+		)
+		if err != nil {
+			return false, fmt.Errorf("시즌 결과 저장 실패: %v", err)
+		}
+	}
+
+	if _, err := tx.Exec(`UPDATE seasons SET closed_at = $1 WHERE id = $2`, now, season.ID); err != nil {
+		return false, fmt.Errorf("시즌 확정 실패: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("시즌 확정 커밋 실패: %v", err)
+	}
+	return true, nil
+}
+
+// CloseEnded 함수는 종료 시각이 지났지만 아직 확정되지 않은 모든 시즌을 확정하고, 확정한 시즌 수를 반환합니다.
+func (s *Service) CloseEnded() (int, error) {
+	rows, err := s.db.Query(
+		`SELECT id FROM seasons WHERE closed_at IS NULL AND ends_at <= $1 ORDER BY ends_at ASC`,
+		time.Now(),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("종료된 시즌 조회 실패: %v", err)
+	}
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("종료된 시즌 데이터 처리 실패: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("종료된 시즌 조회 실패: %v", err)
+	}
+
+	closed := 0
+	for _, id := range ids {
+		ok, err := s.Close(id)
+		if err != nil {
+			return closed, fmt.Errorf("시즌 %d 확정 실패: %v", id, err)
+		}
+		if ok {
+			closed++
+		}
+	}
+	return closed, nil
+}
+
+// Run 함수는 interval마다 종료된 시즌을 확정합니다. ctx가 취소될 때까지 실행되므로 고루틴으로 호출합니다.
+func (s *Service) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if closed, err := s.CloseEnded(); err != nil {
+			log.Printf("시즌 확정 작업 실패: %v", err)
+		} else if closed > 0 {
+			log.Printf("시즌 %d개의 최종 순위를 보관했습니다", closed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
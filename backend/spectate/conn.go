@@ -0,0 +1,28 @@
+package spectate
+
+import (
+	"github.com/gorilla/websocket"
+
+	"games/backend/wsconn"
+)
+
+// maxMessageSize 중계자 메시지 최대 크기입니다. (보드 20×10 기준 여유 있게)
+const maxMessageSize = 8 * 1024
+
+// readLoop 함수는 연결이 끊길 때까지 메시지를 읽어 handle로 넘깁니다. 끝나면 done을 닫습니다.
+// 관전자 연결은 handle이 nil이며, 받은 메시지를 버리고 pong 처리와 연결 종료 감지만 합니다.
+func readLoop(conn *websocket.Conn, done chan<- struct{}, handle func(msg clientMessage)) {
+	defer close(done)
+
+	wsconn.KeepAlive(conn, maxMessageSize)
+
+	for {
+		var msg clientMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			return
+		}
+		if handle != nil {
+			handle(msg)
+		}
+	}
+}
@@ -0,0 +1,271 @@
+package spectate
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"games/backend/pubsub"
+	"games/backend/wsconn"
+)
+
+// Hub 사용자별 중계와 관전자를 관리합니다. 사용자마다 중계 연결은 하나이며, 새로 연결하면 이전 중계를 대체합니다.
+type Hub struct {
+	mu      sync.Mutex
+	streams map[int]*stream
+	seq     uint64 // 마지막으로 발행한 메시지 순서
+
+	frames    *pubsub.Hub[*frame] // 주제는 중계자 사용자 ID
+	stopped   chan struct{}       // Close하면 닫힘
+	closeOnce sync.Once
+}
+
+// stream 사용자 한 명의 현재 중계입니다.
+type stream struct {
+	userID   int
+	nickname string
+	latest   *frame        // 가장 최근 보드 (아직 보드를 받지 않았거나 게임이 끝났으면 nil)
+	replaced chan struct{} // 같은 사용자가 새 중계를 시작하면 닫힘
+}
+
+// Live 지금 중계 중인 게임 정보입니다.
+type Live struct {
+	UserID   int    `json:"user_id"`
+	Nickname string `json:"nickname"`
+	Score    int    `json:"score"`
+	Lines    int    `json:"lines"`
+	Level    int    `json:"level"`
+	Watchers int    `json:"watchers"` // 현재 관전자 수
+}
+
+// NewHub 함수는 중계 허브를 생성합니다.
+func NewHub() *Hub {
+	return &Hub{
+		streams: map[int]*stream{},
+		frames:  pubsub.NewHub[*frame](),
+		stopped: make(chan struct{}),
+	}
+}
+
+// topic 함수는 사용자 중계의 발행 주제를 반환합니다.
+func topic(userID int) string {
+	return strconv.Itoa(userID)
+}
+
+// Broadcast 함수는 conn으로 받은 userID의 보드를 관전자에게 중계합니다.
+// 연결이 끊기거나, 같은 사용자가 새 연결로 중계를 시작하거나, 허브가 닫힐 때까지 반환하지 않으며 반환할 때 연결을 닫습니다.
+func (h *Hub) Broadcast(conn *websocket.Conn, userID int, nickname string) {
+	defer conn.Close()
+
+	s := h.startStream(userID, nickname)
+	reason := ReasonDisconnect
+	defer func() { h.endStream(s, reason) }()
+
+	// 잘못된 메시지에 대한 응답 (쓰기는 이 고루틴만 함)
+	replies := make(chan serverMessage, 4)
+	done := make(chan struct{})
+	go readLoop(conn, done, func(msg clientMessage) {
+		if reply := h.handle(s, msg); reply != nil {
+			select {
+			case replies <- *reply:
+			default:
+			}
+		}
+	})
+
+	ticker := time.NewTicker(wsconn.PingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case reply := <-replies:
+			if err := wsconn.WriteJSON(conn, reply); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := wsconn.WritePing(conn); err != nil {
+				return
+			}
+		case <-s.replaced:
+			reason = ReasonReplaced
+			wsconn.WriteJSON(conn, serverMessage{Type: MsgEnded, UserID: userID, Reason: ReasonReplaced})
+			wsconn.WriteClose(conn)
+			return
+		case <-h.stopped:
+			reason = ReasonShutdown
+			wsconn.WriteClose(conn)
+			return
+		}
+	}
+}
+
+// Watch 함수는 conn을 userID 중계의 관전자로 붙입니다. 먼저 가장 최근 보드(중계 중이 아니면 offline)를 보내고,
+// 이후 중계자가 보내는 보드를 전달합니다. 관전자가 느리면 밀린 보드는 버리고 최신 보드만 보냅니다.
+// 연결이 끊기거나 허브가 닫힐 때까지 반환하지 않으며 반환할 때 연결을 닫습니다.
+func (h *Hub) Watch(conn *websocket.Conn, userID int) {
+	defer conn.Close()
+
+	// 구독한 뒤 최근 보드를 읽어야 그 사이에 발행된 메시지를 놓치지 않음
+	sub := h.frames.Subscribe(topic(userID))
+	defer sub.Close()
+
+	current, lastSeq := h.current(userID)
+	if err := wsconn.WriteJSON(conn, current); err != nil {
+		return
+	}
+
+	done := make(chan struct{})
+	go readLoop(conn, done, nil)
+
+	ticker := time.NewTicker(wsconn.PingPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case f, ok := <-sub.C():
+			if !ok {
+				wsconn.WriteClose(conn) // 서버 종료
+				return
+			}
+			if f.seq <= lastSeq {
+				continue
+			}
+			lastSeq = f.seq
+			if err := wsconn.WriteJSON(conn, f.msg); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := wsconn.WritePing(conn); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// List 함수는 지금 보드를 중계 중인 게임 목록을 관전자가 많은 순서로 반환합니다.
+func (h *Hub) List() []Live {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	lives := []Live{}
+	for _, s := range h.streams {
+		if s.latest == nil {
+			continue
+		}
+		lives = append(lives, Live{
+			UserID:   s.userID,
+			Nickname: s.nickname,
+			Score:    s.latest.msg.Score,
+			Lines:    s.latest.msg.Lines,
+			Level:    s.latest.msg.Level,
+			Watchers: h.frames.Subscribers(topic(s.userID)),
+		})
+	}
+	sort.Slice(lives, func(i, j int) bool {
+		if lives[i].Watchers != lives[j].Watchers {
+			return lives[i].Watchers > lives[j].Watchers
+		}
+		return lives[i].UserID < lives[j].UserID
+	})
+	return lives
+}
+
+// Close 함수는 모든 중계자와 관전자의 연결을 닫습니다.
+func (h *Hub) Close() {
+	h.closeOnce.Do(func() {
+		close(h.stopped)
+		h.frames.Close()
+	})
+}
+
+// startStream 함수는 userID의 새 중계를 등록하고, 이전 중계가 있으면 대체되었음을 알립니다.
+func (h *Hub) startStream(userID int, nickname string) *stream {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if old := h.streams[userID]; old != nil {
+		close(old.replaced)
+	}
+	s := &stream{userID: userID, nickname: nickname, replaced: make(chan struct{})}
+	h.streams[userID] = s
+	return s
+}
+
+// endStream 함수는 중계를 끝내고 관전자에게 알립니다. 이미 새 중계로 대체되었으면 아무것도 하지 않습니다.
+func (h *Hub) endStream(s *stream, reason string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.streams[s.userID] != s {
+		return
+	}
+	delete(h.streams, s.userID)
+	h.publish(s, serverMessage{Type: MsgEnded, UserID: s.userID, Reason: reason})
+}
+
+// handle 함수는 중계자 메시지를 처리합니다. 잘못된 메시지면 중계자에게 보낼 오류를 반환합니다.
+func (h *Hub) handle(s *stream, msg clientMessage) *serverMessage {
+	switch msg.Type {
+	case MsgBoard:
+		if !msg.valid() {
+			return &serverMessage{Type: MsgError, Message: "잘못된 보드 상태입니다"}
+		}
+		h.update(s, serverMessage{
+			Type:     MsgBoard,
+			UserID:   s.userID,
+			Nickname: s.nickname,
+			Board:    msg.Board,
+			Score:    msg.Score,
+			Lines:    msg.Lines,
+			Level:    msg.Level,
+		})
+	case MsgGameOver:
+		h.update(s, serverMessage{Type: MsgEnded, UserID: s.userID, Reason: ReasonGameOver})
+	default:
+		return &serverMessage{Type: MsgError, Message: "알 수 없는 메시지 종류입니다"}
+	}
+	return nil
+}
+
+// update 함수는 현재 중계의 메시지를 발행하고, 보드면 늦게 들어올 관전자를 위해 보관합니다.
+// 연결이 끊기는 중이거나 대체된 중계의 메시지는 버립니다.
+func (h *Hub) update(s *stream, msg serverMessage) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.streams[s.userID] != s {
+		return
+	}
+	f := h.publish(s, msg)
+	if msg.Type == MsgBoard {
+		s.latest = f
+	} else {
+		s.latest = nil
+	}
+}
+
+// publish 함수는 메시지에 순서를 매겨 관전자에게 발행합니다. h.mu를 잡은 상태에서 호출해야 합니다.
+func (h *Hub) publish(s *stream, msg serverMessage) *frame {
+	h.seq++
+	f := &frame{seq: h.seq, msg: msg}
+	h.frames.Publish(topic(s.userID), f)
+	return f
+}
+
+// current 함수는 새 관전자에게 처음 보낼 메시지와 그 순서를 반환합니다.
+func (h *Hub) current(userID int) (serverMessage, uint64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if s := h.streams[userID]; s != nil && s.latest != nil {
+		return s.latest.msg, s.latest.seq
+	}
+	return serverMessage{Type: MsgOffline, UserID: userID}, h.seq
+}
@@ -0,0 +1,59 @@
+// spectate 패키지는 진행 중인 테트리스 게임을 WebSocket으로 중계하고 관전하는 기능을 담당합니다.
+// 플레이어가 보낸 보드 상태 중 가장 최근 것만 보관해, 늦게 들어온 관전자도 바로 현재 보드를 받습니다.
+package spectate
+
+import "games/backend/tetris"
+
+// 중계자 -> 서버 메시지 종류입니다.
+const (
+	MsgBoard    = "board"    // 현재 보드와 점수 (관전자에게 중계)
+	MsgGameOver = "gameover" // 게임 오버 (관전자에게 게임 종료 알림)
+)
+
+// 서버 -> 클라이언트 메시지 종류입니다. 보드 메시지는 MsgBoard를 그대로 사용합니다.
+const (
+	MsgOffline = "offline" // 관전 대상이 지금 중계하고 있지 않음
+	MsgEnded   = "ended"   // 중계 중인 게임이 끝남
+	MsgError   = "error"   // 잘못된 메시지 (연결은 유지)
+)
+
+// 중계 종료 사유입니다.
+const (
+	ReasonGameOver   = "gameover"   // 게임 오버
+	ReasonDisconnect = "disconnect" // 중계자의 연결이 끊김
+	ReasonReplaced   = "replaced"   // 같은 사용자가 새 연결로 중계를 다시 시작 (이전 중계자에게만 보냄)
+	ReasonShutdown   = "shutdown"   // 서버 종료
+)
+
+// clientMessage 중계자가 보내는 메시지입니다.
+type clientMessage struct {
+	Type  string  `json:"type"`
+	Board [][]int `json:"board,omitempty"` // board: 행(위에서 아래) × 열
+	Score int     `json:"score,omitempty"` // board
+	Lines int     `json:"lines,omitempty"` // board
+	Level int     `json:"level,omitempty"` // board
+}
+
+// valid 함수는 board 메시지의 보드와 점수가 올바른지 확인합니다.
+func (m *clientMessage) valid() bool {
+	return tetris.ValidBoard(m.Board) && m.Score >= 0 && m.Lines >= 0 && m.Level >= 0
+}
+
+// serverMessage 서버가 보내는 메시지입니다.
+type serverMessage struct {
+	Type     string  `json:"type"`
+	UserID   int     `json:"user_id,omitempty"`  // board, offline, ended: 관전 대상
+	Nickname string  `json:"nickname,omitempty"` // board
+	Board    [][]int `json:"board,omitempty"`    // board
+	Score    int     `json:"score,omitempty"`    // board
+	Lines    int     `json:"lines,omitempty"`    // board
+	Level    int     `json:"level,omitempty"`    // board
+	Reason   string  `json:"reason,omitempty"`   // ended
+	Message  string  `json:"message,omitempty"`  // error
+}
+
+// frame 관전자에게 발행하는 메시지입니다. seq는 발행 순서이며, 관전자는 이미 보낸 메시지보다 오래된 것을 무시합니다.
+type frame struct {
+	seq uint64
+	msg serverMessage
+}
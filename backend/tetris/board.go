@@ -0,0 +1,22 @@
+package tetris
+
+// MaxCell 게임판 칸에 올 수 있는 가장 큰 값입니다. (0 빈칸, 1~7 블록 색상, 8 가비지)
+const MaxCell = garbageCell
+
+// ValidBoard 함수는 클라이언트가 보낸 게임판(행은 위에서 아래)의 크기와 칸 값이 테트리스 게임판과 맞는지 확인합니다.
+func ValidBoard(board [][]int) bool {
+	if len(board) != Rows {
+		return false
+	}
+	for _, row := range board {
+		if len(row) != Cols {
+			return false
+		}
+		for _, cell := range row {
+			if cell < 0 || cell > MaxCell {
+				return false
+			}
+		}
+	}
+	return true
+}
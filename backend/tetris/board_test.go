@@ -0,0 +1,34 @@
+package tetris
+
+import "testing"
+
+func TestValidBoard(t *testing.T) {
+	board := func(edit func(b [][]int) [][]int) [][]int {
+		b := make([][]int, Rows)
+		for i := range b {
+			b[i] = make([]int, Cols)
+		}
+		return edit(b)
+	}
+
+	cases := []struct {
+		name  string
+		board [][]int
+		want  bool
+	}{
+		{"빈 게임판", board(func(b [][]int) [][]int { return b }), true},
+		{"가비지 칸", board(func(b [][]int) [][]int { b[Rows-1][0] = MaxCell; return b }), true},
+		{"nil", nil, false},
+		{"행 부족", board(func(b [][]int) [][]int { return b[1:] }), false},
+		{"열 부족", board(func(b [][]int) [][]int { b[3] = b[3][1:]; return b }), false},
+		{"음수 칸", board(func(b [][]int) [][]int { b[0][0] = -1; return b }), false},
+		{"큰 칸 값", board(func(b [][]int) [][]int { b[0][0] = MaxCell + 1; return b }), false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ValidBoard(tc.board); got != tc.want {
+				t.Fatalf("ValidBoard = %v, 기대값 %v", got, tc.want)
+			}
+		})
+	}
+}
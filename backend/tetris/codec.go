@@ -0,0 +1,98 @@
+package tetris
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// 압축 리플레이 형식 (gzip 안의 내용, 모든 정수는 uvarint)
+//
+//	버전, 시드, 스텝 수, 입력 수, (이전 입력과의 스텝 차이, 입력 종류 1바이트) × 입력 수
+//
+// 입력 대부분은 몇 스텝 간격으로 이어지므로 JSON보다 훨씬 작습니다.
+
+// EncodeReplay 함수는 리플레이를 저장용 압축 형식으로 변환해 압축 데이터와 압축 전 크기를 반환합니다.
+// 형식이 잘못된 리플레이는 변환하지 않습니다.
+func EncodeReplay(r *Replay) ([]byte, int, error) {
+	if err := r.Validate(); err != nil {
+		return nil, 0, err
+	}
+
+	raw := make([]byte, 0, 4*binary.MaxVarintLen32+len(r.Inputs)*3)
+	raw = binary.AppendUvarint(raw, uint64(r.Version))
+	raw = binary.AppendUvarint(raw, uint64(r.Seed))
+	raw = binary.AppendUvarint(raw, uint64(r.Steps))
+	raw = binary.AppendUvarint(raw, uint64(len(r.Inputs)))
+	last := 0
+	for _, input := range r.Inputs {
+		raw = binary.AppendUvarint(raw, uint64(input.Step()-last))
+		raw = append(raw, byte(input.Action()))
+		last = input.Step()
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(raw); err != nil {
+		return nil, 0, fmt.Errorf("리플레이 압축 실패: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, 0, fmt.Errorf("리플레이 압축 실패: %v", err)
+	}
+	return buf.Bytes(), len(raw), nil
+}
+
+// DecodeReplay 함수는 EncodeReplay로 압축한 리플레이를 다시 읽습니다.
+func DecodeReplay(data []byte) (*Replay, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidReplay, err)
+	}
+	defer zr.Close()
+	br := bufio.NewReader(io.LimitReader(zr, int64(MaxReplayInputs)*(binary.MaxVarintLen32+1)+4*binary.MaxVarintLen64))
+
+	var header [4]uint64
+	for i := range header {
+		if header[i], err = binary.ReadUvarint(br); err != nil {
+			return nil, fmt.Errorf("%w: 헤더를 읽을 수 없습니다: %v", ErrInvalidReplay, err)
+		}
+	}
+	if header[3] > MaxReplayInputs || header[2] > MaxReplaySteps || header[1] > 0xFFFFFFFF {
+		return nil, fmt.Errorf("%w: 헤더 값이 범위를 벗어났습니다", ErrInvalidReplay)
+	}
+
+	r := &Replay{
+		Version: int(header[0]),
+		Seed:    uint32(header[1]),
+		Steps:   int(header[2]),
+		Inputs:  make([]Input, header[3]),
+	}
+	step := 0
+	for i := range r.Inputs {
+		delta, err := binary.ReadUvarint(br)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %d번째 입력을 읽을 수 없습니다: %v", ErrInvalidReplay, i, err)
+		}
+		action, err := br.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %d번째 입력을 읽을 수 없습니다: %v", ErrInvalidReplay, i, err)
+		}
+		if delta > MaxReplaySteps {
+			return nil, fmt.Errorf("%w: %d번째 입력의 스텝이 범위를 벗어났습니다", ErrInvalidReplay, i)
+		}
+		step += int(delta)
+		r.Inputs[i] = Input{step, int(action)}
+	}
+	// 끝까지 읽어야 gzip 체크섬을 검사하므로 남은 데이터가 없는지 확인
+	if _, err := br.ReadByte(); err != io.EOF {
+		return nil, fmt.Errorf("%w: 입력 뒤에 데이터가 남아 있거나 손상되었습니다", ErrInvalidReplay)
+	}
+
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
@@ -0,0 +1,74 @@
+package tetris
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestReplayRoundTrip(t *testing.T) {
+	cases := map[string]*Replay{
+		"입력 없음":     {Version: ReplayVersion, Seed: 0, Steps: 0, Inputs: []Input{}},
+		"같은 스텝 입력":  {Version: ReplayVersion, Seed: 1, Steps: 3, Inputs: []Input{{0, 3}, {0, 3}, {0, 0}, {3, 4}}},
+		"최대 시드와 스텝": {Version: ReplayVersion, Seed: 0xFFFFFFFF, Steps: MaxReplaySteps, Inputs: []Input{{0, 1}, {MaxReplaySteps, 2}}},
+	}
+	for _, game := range parity(t).Games {
+		cases[fmt.Sprintf("tetris.js %d", game.Seed)] = game.replay()
+	}
+
+	for name, want := range cases {
+		t.Run(name, func(t *testing.T) {
+			data, rawSize, err := EncodeReplay(want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if rawSize <= 0 {
+				t.Fatalf("압축 전 크기 = %d", rawSize)
+			}
+			got, err := DecodeReplay(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("DecodeReplay = %+v, 원본 = %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestEncodeReplayRejectsInvalid(t *testing.T) {
+	cases := map[string]*Replay{
+		"버전":        {Version: ReplayVersion + 1, Inputs: []Input{}},
+		"스텝 역순":     {Version: ReplayVersion, Steps: 5, Inputs: []Input{{3, 0}, {2, 0}}},
+		"스텝 초과":     {Version: ReplayVersion, Steps: 5, Inputs: []Input{{6, 0}}},
+		"알 수 없는 입력": {Version: ReplayVersion, Steps: 5, Inputs: []Input{{1, int(ActionHardDrop) + 1}}},
+	}
+	for name, r := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, _, err := EncodeReplay(r); !errors.Is(err, ErrInvalidReplay) {
+				t.Fatalf("EncodeReplay 오류 = %v, ErrInvalidReplay가 아닙니다", err)
+			}
+		})
+	}
+}
+
+func TestDecodeReplayRejectsCorrupt(t *testing.T) {
+	valid, _, err := EncodeReplay(&Replay{Version: ReplayVersion, Seed: 7, Steps: 10, Inputs: []Input{{2, 0}, {5, 4}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := map[string][]byte{
+		"gzip 아님": []byte("not a replay"),
+		"잘림":      valid[:len(valid)-8],
+		"빈 데이터":   {},
+	}
+	for name, data := range cases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := DecodeReplay(data); !errors.Is(err, ErrInvalidReplay) {
+				t.Fatalf("DecodeReplay 오류 = %v, ErrInvalidReplay가 아닙니다", err)
+			}
+		})
+	}
+}
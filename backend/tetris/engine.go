@@ -0,0 +1,478 @@
+// tetris 패키지는 frontend/games/tetris/tetris.js와 같은 규칙의 테트리스 엔진과 리플레이 검증을 정의합니다.
+// 브라우저 게임은 STEP_MS 단위 고정 스텝으로 진행되므로, 같은 시드와 입력 기록을 넣으면 서버에서도 같은 결과가 나옵니다.
+// tetris.js의 게임 규칙을 바꾸면 이 패키지도 같이 바꾸고 ReplayVersion을 올려야 합니다.
+package tetris
+
+import "math"
+
+// 게임 상수 (tetris.js와 같은 값)
+const (
+	Cols = 10 // 게임판 가로 칸 수
+	Rows = 20 // 게임판 세로 칸 수
+
+	StepMs          = 10    // 시뮬레이션 한 스텝의 게임 시간 (밀리초)
+	LevelUpInterval = 30000 // 레벨업 간격: 30초
+	LockDelay       = 500   // 잠금 딜레이 시간 (밀리초)
+	MaxLockMoves    = 15    // 최대 잠금 상태 이동 횟수
+	GarbageInterval = 12000 // 레벨 2의 가비지 라인 추가 간격 (밀리초)
+	MinGarbageDelay = 5000  // 가비지 라인 최소 간격 (밀리초)
+
+	garbageCell = 8 // 가비지(검은 블록) 칸 값
+)
+
+// LinePoints 한 번에 지운 줄 수(1~4)별 기본 점수입니다. 실제 점수는 여기에 레벨을 곱합니다.
+var LinePoints = [4]int{40, 100, 300, 1200}
+
+// Action 플레이어 입력 종류입니다. 값은 리플레이 입력 기록에 그대로 저장됩니다.
+type Action int
+
+const (
+	ActionLeft     Action = iota // 왼쪽 이동
+	ActionRight                  // 오른쪽 이동
+	ActionSoftDrop               // 한 칸 내리기
+	ActionRotate                 // 회전
+	ActionHardDrop               // 바로 내리기
+)
+
+// shapes 테트로미노 모양 (인덱스 1~7: I, J, L, O, S, T, Z)
+var shapes = [8][][]int{
+	{},
+	{{0, 0, 0, 0}, {1, 1, 1, 1}, {0, 0, 0, 0}, {0, 0, 0, 0}},
+	{{2, 0, 0}, {2, 2, 2}, {0, 0, 0}},
+	{{0, 0, 3}, {3, 3, 3}, {0, 0, 0}},
+	{{4, 4}, {4, 4}},
+	{{0, 5, 5}, {5, 5, 0}, {0, 0, 0}},
+	{{0, 6, 0}, {6, 6, 6}, {0, 0, 0}},
+	{{7, 7, 0}, {0, 7, 7}, {0, 0, 0}},
+}
+
+// kicks 회전 충돌 시 시도하는 벽 킥 위치 (tetris.js와 같은 순서)
+var kicks = [][2]int{{1, 0}, {-1, 0}, {0, -1}, {1, -1}, {-1, -1}, {2, 0}, {-2, 0}}
+
+// piece 현재 움직이는 블록입니다.
+type piece struct {
+	kind  int
+	shape [][]int
+	x, y  int
+}
+
+// Game 테트리스 한 판의 상태입니다. NewGame으로 만들고 Step과 Apply로 진행합니다.
+type Game struct {
+	board [Rows][Cols]int
+	rng   *rng
+	bag   []int
+
+	piece *piece
+	next  *piece
+
+	score int
+	lines int
+	level int
+
+	combo             int
+	lastClearWasCombo bool
+
+	now            int // 게임 시간 (밀리초, 스텝 수 × StepMs)
+	steps          int
+	dropInterval   int
+	dropStart      int
+	isLocked       bool
+	lockDelayStart int
+	lockMoves      int
+	lastGarbage    int
+
+	piecesPlaced    int
+	maxCombo        int
+	garbageSurvived int
+
+	gameOver bool
+}
+
+// NewGame 함수는 시드로 새 게임을 시작합니다. (tetris.js의 startGame)
+func NewGame(seed uint32) *Game {
+	g := &Game{
+		rng:          newRNG(seed),
+		level:        1,
+		dropInterval: 500,
+	}
+	g.bag = g.generateBag()
+	g.piece = g.nextPiece()
+	g.next = g.nextPiece()
+	return g
+}
+
+// GameOver 함수는 게임이 끝났는지 반환합니다.
+func (g *Game) GameOver() bool {
+	return g.gameOver
+}
+
+// Steps 함수는 지금까지 진행한 스텝 수를 반환합니다.
+func (g *Game) Steps() int {
+	return g.steps
+}
+
+// Combo 함수는 현재 콤보(줄을 연속으로 지운 횟수)를 반환합니다. 마지막으로 고정한 블록이 줄을 지우지 못했으면 0입니다.
+func (g *Game) Combo() int {
+	return g.combo
+}
+
+// Board 함수는 현재 게임판(고정된 블록만, 행은 위에서 아래)을 반환합니다.
+func (g *Game) Board() [Rows][Cols]int {
+	return g.board
+}
+
+// AddGarbage 함수는 대전 상대가 보낸 가비지 줄을 게임판 아래에 추가합니다. (tetris.js의 addGarbageLines)
+// 빈칸 위치는 레벨 가비지와 같은 난수 생성기로 정하므로 브라우저와 같은 순서로 호출해야 합니다. 게임이 끝났으면 무시합니다.
+func (g *Game) AddGarbage(count int) {
+	if g.gameOver || count <= 0 {
+		return
+	}
+	g.addGarbage(min(count, Rows))
+}
+
+// Result 함수는 현재까지의 게임 결과를 반환합니다.
+func (g *Game) Result() Result {
+	return Result{
+		Score:           g.score,
+		Lines:           g.lines,
+		Level:           g.level,
+		DurationMs:      g.now,
+		PiecesPlaced:    g.piecesPlaced,
+		MaxCombo:        g.maxCombo,
+		GarbageSurvived: g.garbageSurvived,
+		Steps:           g.steps,
+	}
+}
+
+// generateBag 함수는 섞인 7-bag을 만듭니다. (Fisher-Yates)
+func (g *Game) generateBag() []int {
+	bag := []int{1, 2, 3, 4, 5, 6, 7}
+	for i := len(bag) - 1; i > 0; i-- {
+		j := g.rng.intn(i + 1)
+		bag[i], bag[j] = bag[j], bag[i]
+	}
+	return bag
+}
+
+// nextPiece 함수는 가방 끝에서 다음 블록을 꺼냅니다. 가방이 비어 있으면 새로 만듭니다.
+func (g *Game) nextPiece() *piece {
+	if len(g.bag) == 0 {
+		g.bag = g.generateBag()
+	}
+	kind := g.bag[len(g.bag)-1]
+	g.bag = g.bag[:len(g.bag)-1]
+
+	// I 블록은 한 줄, 나머지는 두 줄 위에서 시작
+	y := -2
+	if kind == 1 {
+		y = -1
+	}
+	return &piece{kind: kind, shape: shapes[kind], x: Cols/2 - 1, y: y}
+}
+
+// collides 함수는 블록이 벽, 바닥 또는 다른 블록과 겹치는지 확인합니다.
+func (g *Game) collides(p *piece) bool {
+	for row := range p.shape {
+		for col := range p.shape[row] {
+			if p.shape[row][col] == 0 {
+				continue
+			}
+			x, y := p.x+col, p.y+row
+			if x < 0 || x >= Cols || y >= Rows || (y >= 0 && g.board[y][x] != 0) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// downCollides 함수는 현재 블록이 한 칸 아래로 내려가면 충돌하는지 확인합니다.
+func (g *Game) downCollides() bool {
+	g.piece.y++
+	collides := g.collides(g.piece)
+	g.piece.y--
+	return collides
+}
+
+// updateLock 함수는 이동/회전에 성공한 뒤 잠금 상태를 다시 계산합니다.
+func (g *Game) updateLock() {
+	if !g.downCollides() {
+		g.isLocked = false
+		return
+	}
+	if g.isLocked {
+		// 아래로 여전히 충돌이면 잠금 딜레이를 유지하되 타이머를 리셋 (최대 이동 횟수까지)
+		g.lockMoves++
+		if g.lockMoves < MaxLockMoves {
+			g.lockDelayStart = g.now
+		}
+		return
+	}
+	g.isLocked = true
+	g.lockDelayStart = g.now
+	g.lockMoves = 0
+}
+
+// move 함수는 블록을 좌우로 이동합니다.
+func (g *Game) move(dir int) {
+	g.piece.x += dir
+	if g.collides(g.piece) {
+		g.piece.x -= dir
+		return
+	}
+	g.updateLock()
+}
+
+// rotate 함수는 블록을 시계 방향으로 회전합니다. 충돌하면 벽 킥을 순서대로 시도합니다.
+func (g *Game) rotate() {
+	originalX, originalY := g.piece.x, g.piece.y
+	original := g.piece.shape
+
+	rotated := make([][]int, len(original[0]))
+	for y := range rotated {
+		rotated[y] = make([]int, len(original))
+		for x := range rotated[y] {
+			rotated[y][x] = original[len(original)-1-x][y]
+		}
+	}
+	g.piece.shape = rotated
+
+	if g.collides(g.piece) {
+		kicked := false
+		for _, kick := range kicks {
+			g.piece.x += kick[0]
+			g.piece.y += kick[1]
+			if !g.collides(g.piece) {
+				kicked = true
+				break
+			}
+			g.piece.x, g.piece.y = originalX, originalY
+		}
+		if !kicked {
+			g.piece.shape = original
+			return
+		}
+	}
+	g.updateLock()
+}
+
+// drop 함수는 블록을 한 칸 내립니다. 바닥에 닿으면 잠금 딜레이를 시작합니다.
+func (g *Game) drop() {
+	g.piece.y++
+	if g.collides(g.piece) {
+		g.piece.y--
+		if !g.isLocked {
+			g.isLocked = true
+			g.lockDelayStart = g.now
+			g.lockMoves = 0
+		}
+	} else {
+		g.isLocked = false
+	}
+	g.dropStart = g.now
+}
+
+// hardDrop 함수는 블록을 바닥까지 내리고 바로 고정합니다.
+func (g *Game) hardDrop() {
+	for !g.collides(g.piece) {
+		g.piece.y++
+	}
+	g.piece.y--
+	g.lock()
+	g.isLocked = false
+}
+
+// lock 함수는 블록을 게임판에 고정하고 줄을 지운 뒤 다음 블록을 꺼냅니다.
+func (g *Game) lock() {
+	for row := range g.piece.shape {
+		for col := range g.piece.shape[row] {
+			if g.piece.shape[row][col] == 0 {
+				continue
+			}
+			y, x := g.piece.y+row, g.piece.x+col
+			if y < 0 {
+				// 게임판 위로 넘어감
+				g.gameOver = true
+				return
+			}
+			g.board[y][x] = g.piece.kind
+		}
+	}
+
+	g.piecesPlaced++
+	g.clearLines()
+
+	newPiece := g.next
+	g.next = g.nextPiece()
+	g.piece = newPiece
+	if g.collides(newPiece) {
+		// 새 블록이 기존 블록과 겹치면 게임 오버
+		g.gameOver = true
+	}
+}
+
+// clearLines 함수는 가득 찬 줄을 지우고 점수와 콤보를 계산합니다.
+func (g *Game) clearLines() {
+	cleared := 0
+	for row := Rows - 1; row >= 0; row-- {
+		full := true
+		for col := 0; col < Cols; col++ {
+			if g.board[row][col] == 0 {
+				full = false
+				break
+			}
+		}
+		if !full {
+			continue
+		}
+
+		cleared++
+		for r := row; r > 0; r-- {
+			g.board[r] = g.board[r-1]
+		}
+		g.board[0] = [Cols]int{}
+		row++ // 같은 줄을 다시 검사
+	}
+
+	if cleared == 0 {
+		g.lastClearWasCombo = false
+		g.combo = 0
+		return
+	}
+
+	if g.lastClearWasCombo {
+		g.combo++
+	} else {
+		g.combo = 1
+		g.lastClearWasCombo = true
+	}
+	if g.combo > g.maxCombo {
+		g.maxCombo = g.combo
+	}
+
+	// 줄 점수 × 레벨 + 콤보 보너스 (콤보 - 1) × 50 × 레벨
+	g.score += LinePoints[min(cleared, 4)-1] * g.level
+	if g.combo > 1 {
+		g.score += (g.combo - 1) * 50 * g.level
+	}
+	g.lines += cleared
+}
+
+// garbageLine 함수는 레벨에 따라 빈칸 수가 정해지는 가비지 줄을 만듭니다.
+func (g *Game) garbageLine() [Cols]int {
+	empty := min(5, 1+int(math.Floor(float64(g.level-2)/1.6)))
+	if empty < 1 {
+		empty = 1
+	}
+
+	var line [Cols]int
+	for col := range line {
+		line[col] = garbageCell
+	}
+
+	var positions []int
+	for len(positions) < empty {
+		pos := g.rng.intn(Cols)
+		exists := false
+		for _, p := range positions {
+			if p == pos {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			positions = append(positions, pos)
+			line[pos] = 0
+		}
+	}
+	return line
+}
+
+// addGarbage 함수는 게임판 아래에 가비지 줄을 추가하고 블록을 그만큼 위로 올립니다.
+func (g *Game) addGarbage(count int) {
+	originalY := g.piece.y
+
+	// 맨 윗줄에 블록이 있으면 게임 오버
+	for col := 0; col < Cols; col++ {
+		if g.board[0][col] != 0 {
+			g.gameOver = true
+			return
+		}
+	}
+
+	for row := 0; row < Rows-count; row++ {
+		g.board[row] = g.board[row+count]
+	}
+	for i := 0; i < count; i++ {
+		g.board[Rows-count+i] = g.garbageLine()
+	}
+	g.garbageSurvived += count
+
+	g.piece.y -= count
+	if g.collides(g.piece) {
+		g.piece.y = originalY
+		if g.collides(g.piece) {
+			g.gameOver = true
+		}
+	}
+}
+
+// Apply 함수는 플레이어 입력 하나를 현재 게임 시간에 적용합니다. 게임이 끝났으면 무시합니다.
+func (g *Game) Apply(action Action) {
+	if g.gameOver {
+		return
+	}
+	switch action {
+	case ActionLeft:
+		g.move(-1)
+	case ActionRight:
+		g.move(1)
+	case ActionSoftDrop:
+		g.drop()
+	case ActionRotate:
+		g.rotate()
+	case ActionHardDrop:
+		g.hardDrop()
+	}
+}
+
+// Step 함수는 게임 시간을 StepMs만큼 진행합니다. (tetris.js의 stepGame)
+// 레벨업, 잠금 딜레이, 중력, 가비지 추가 순서로 처리합니다.
+func (g *Game) Step() {
+	if g.gameOver {
+		return
+	}
+	g.steps++
+	g.now = g.steps * StepMs
+
+	// 30초마다 레벨업, 레벨에 따라 낙하 속도 증가
+	if level := g.now/LevelUpInterval + 1; level > g.level {
+		g.level = level
+		g.dropInterval = max(50, 500-(g.level-1)*40)
+	}
+
+	if g.isLocked {
+		if g.now-g.lockDelayStart > LockDelay || g.lockMoves >= MaxLockMoves {
+			g.lock()
+			g.isLocked = false
+		}
+	} else if g.now-g.dropStart > g.dropInterval {
+		g.drop()
+	}
+	if g.gameOver {
+		return
+	}
+
+	// 레벨 2 이상에서 가비지 라인 추가 (레벨이 높을수록 자주, 레벨 10 이상은 두 줄씩)
+	if g.level >= 2 {
+		interval := max(MinGarbageDelay, GarbageInterval-(g.level-2)*500)
+		if g.now-g.lastGarbage > interval {
+			count := 1
+			if g.level >= 10 {
+				count = 2
+			}
+			g.addGarbage(count)
+			g.lastGarbage = g.now
+		}
+	}
+}
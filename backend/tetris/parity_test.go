@@ -0,0 +1,196 @@
+package tetris
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+// parityFixture testdata/gen_parity.js가 tetris.js를 실행해 기록한 기대값입니다.
+type parityFixture struct {
+	ReplayVersion int `json:"replayVersion"`
+	RNG           []struct {
+		Seed   uint32    `json:"seed"`
+		Values []float64 `json:"values"`
+	} `json:"rng"`
+	Bags []struct {
+		Seed uint32  `json:"seed"`
+		Bags [][]int `json:"bags"`
+	} `json:"bags"`
+	Scoring []struct {
+		Level             int  `json:"level"`
+		Cleared           int  `json:"cleared"`
+		Combo             int  `json:"combo"`
+		LastClearWasCombo bool `json:"lastClearWasCombo"`
+		Score             int  `json:"score"`
+		Lines             int  `json:"lines"`
+		ComboAfter        int  `json:"comboAfter"`
+	} `json:"scoring"`
+	Games []parityGame `json:"games"`
+}
+
+// parityGame 봇이 tetris.js로 진행한 한 판의 입력과 결과입니다.
+type parityGame struct {
+	Seed     uint32  `json:"seed"`
+	Steps    int     `json:"steps"`
+	GameOver bool    `json:"gameOver"`
+	Inputs   []Input `json:"inputs"`
+	Result   struct {
+		Score           int `json:"score"`
+		Lines           int `json:"lines"`
+		Level           int `json:"level"`
+		DurationMs      int `json:"durationMs"`
+		PiecesPlaced    int `json:"piecesPlaced"`
+		MaxCombo        int `json:"maxCombo"`
+		GarbageSurvived int `json:"garbageSurvived"`
+		Steps           int `json:"steps"`
+	} `json:"result"`
+	Levels  [][2]int        `json:"levels"`  // [스텝, 오른 레벨]
+	Garbage [][2]int        `json:"garbage"` // [스텝, 추가된 가비지 줄 수]
+	Board   [Rows][Cols]int `json:"board"`
+}
+
+func (g parityGame) replay() *Replay {
+	return &Replay{Version: ReplayVersion, Seed: g.Seed, Steps: g.Steps, Inputs: g.Inputs}
+}
+
+var loadParity = sync.OnceValues(func() (*parityFixture, error) {
+	data, err := os.ReadFile("testdata/parity.json")
+	if err != nil {
+		return nil, err
+	}
+	var f parityFixture
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+	return &f, nil
+})
+
+func parity(t *testing.T) *parityFixture {
+	t.Helper()
+	f, err := loadParity()
+	if err != nil {
+		t.Fatalf("testdata/parity.json을 읽을 수 없습니다 (node testdata/gen_parity.js로 생성): %v", err)
+	}
+	return f
+}
+
+func TestReplayVersionMatchesTetrisJS(t *testing.T) {
+	if f := parity(t); f.ReplayVersion != ReplayVersion {
+		t.Fatalf("tetris.js REPLAY_VERSION = %d, ReplayVersion = %d", f.ReplayVersion, ReplayVersion)
+	}
+}
+
+func TestRNGMatchesTetrisJS(t *testing.T) {
+	for _, tc := range parity(t).RNG {
+		t.Run(fmt.Sprint(tc.Seed), func(t *testing.T) {
+			r := newRNG(tc.Seed)
+			for i, want := range tc.Values {
+				if got := r.next(); got != want {
+					t.Fatalf("%d번째 난수 = %v, tetris.js = %v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestBagMatchesTetrisJS(t *testing.T) {
+	for _, tc := range parity(t).Bags {
+		t.Run(fmt.Sprint(tc.Seed), func(t *testing.T) {
+			g := &Game{rng: newRNG(tc.Seed)}
+			for i, want := range tc.Bags {
+				if got := g.generateBag(); !reflect.DeepEqual(got, want) {
+					t.Fatalf("%d번째 가방 = %v, tetris.js = %v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestScoringMatchesTetrisJS(t *testing.T) {
+	for _, tc := range parity(t).Scoring {
+		name := fmt.Sprintf("level%d/lines%d/combo%d", tc.Level, tc.Cleared, tc.Combo)
+		t.Run(name, func(t *testing.T) {
+			// gen_parity.js와 같은 게임판: 아래 cleared줄은 가득 차고 그 위 줄에 한 칸
+			g := &Game{level: tc.Level, combo: tc.Combo, lastClearWasCombo: tc.LastClearWasCombo}
+			for row := Rows - tc.Cleared; row < Rows; row++ {
+				for col := range g.board[row] {
+					g.board[row][col] = 1
+				}
+			}
+			g.board[Rows-tc.Cleared-1][0] = 1
+
+			g.clearLines()
+			if g.score != tc.Score || g.lines != tc.Lines || g.combo != tc.ComboAfter {
+				t.Fatalf("점수 %d, 줄 %d, 콤보 %d / tetris.js 점수 %d, 줄 %d, 콤보 %d",
+					g.score, g.lines, g.combo, tc.Score, tc.Lines, tc.ComboAfter)
+			}
+			if g.board[Rows-1][0] != 1 || g.board[Rows-1][1] != 0 {
+				t.Fatalf("지운 뒤 맨 아랫줄 = %v", g.board[Rows-1])
+			}
+		})
+	}
+}
+
+// TestGameMatchesTetrisJS 함수는 tetris.js로 진행한 게임을 같은 입력으로 진행해
+// 레벨업과 가비지 추가 시점, 최종 게임판과 결과가 모두 같은지 확인합니다.
+func TestGameMatchesTetrisJS(t *testing.T) {
+	for _, tc := range parity(t).Games {
+		t.Run(fmt.Sprint(tc.Seed), func(t *testing.T) {
+			g := NewGame(tc.Seed)
+			levels, garbage := [][2]int{}, [][2]int{}
+			next := 0
+			for {
+				for next < len(tc.Inputs) && tc.Inputs[next].Step() == g.Steps() && !g.GameOver() {
+					g.Apply(tc.Inputs[next].Action())
+					next++
+				}
+				if g.GameOver() || g.Steps() >= tc.Steps {
+					break
+				}
+
+				level, survived := g.level, g.garbageSurvived
+				g.Step()
+				if g.level != level {
+					levels = append(levels, [2]int{g.Steps(), g.level})
+				}
+				if g.garbageSurvived != survived {
+					garbage = append(garbage, [2]int{g.Steps(), g.garbageSurvived - survived})
+				}
+			}
+
+			if g.GameOver() != tc.GameOver || g.Steps() != tc.Steps {
+				t.Fatalf("게임 오버 %v (%d스텝), tetris.js 게임 오버 %v (%d스텝)", g.GameOver(), g.Steps(), tc.GameOver, tc.Steps)
+			}
+			if !reflect.DeepEqual(levels, tc.Levels) {
+				t.Errorf("레벨업 시점 = %v, tetris.js = %v", levels, tc.Levels)
+			}
+			if !reflect.DeepEqual(garbage, tc.Garbage) {
+				t.Errorf("가비지 추가 시점 = %v, tetris.js = %v", garbage, tc.Garbage)
+			}
+			if g.board != tc.Board {
+				t.Errorf("최종 게임판이 tetris.js와 다릅니다\n%v\n%v", g.board, tc.Board)
+			}
+			if got, want := g.Result(), Result(tc.Result); got != want {
+				t.Errorf("결과 = %+v, tetris.js = %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestSimulateMatchesTetrisJS(t *testing.T) {
+	for _, tc := range parity(t).Games {
+		t.Run(fmt.Sprint(tc.Seed), func(t *testing.T) {
+			result, err := Verify(tc.replay(), tc.Result.Score, tc.Result.Lines, tc.Result.Level)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *result != Result(tc.Result) {
+				t.Fatalf("결과 = %+v, tetris.js = %+v", *result, tc.Result)
+			}
+		})
+	}
+}
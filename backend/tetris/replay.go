@@ -0,0 +1,126 @@
+package tetris
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ReplayVersion 현재 엔진 규칙의 리플레이 버전입니다. tetris.js의 REPLAY_VERSION과 같아야 합니다.
+const ReplayVersion = 1
+
+// 리플레이 크기 제한 (비정상적으로 큰 요청으로 서버 자원을 쓰지 않도록)
+const (
+	MaxReplaySteps  = 2 * 60 * 60 * 1000 / StepMs // 최대 2시간
+	MaxReplayInputs = 200000
+)
+
+var (
+	// ErrInvalidReplay 리플레이 형식이 잘못되었을 때 반환됩니다.
+	ErrInvalidReplay = errors.New("잘못된 리플레이입니다")
+	// ErrReplayMismatch 리플레이로 다시 계산한 결과가 제출된 결과와 다를 때 반환됩니다.
+	ErrReplayMismatch = errors.New("제출된 결과가 리플레이와 일치하지 않습니다")
+)
+
+// Input 리플레이 입력 하나입니다. JSON으로는 [스텝, 입력 종류] 배열입니다.
+// 스텝은 입력 시점까지 진행된 스텝 수이며, 그 다음 스텝을 진행하기 전에 적용됩니다.
+type Input [2]int
+
+// Step 함수는 입력이 적용되는 스텝 수를 반환합니다.
+func (i Input) Step() int { return i[0] }
+
+// Action 함수는 입력 종류를 반환합니다.
+func (i Input) Action() Action { return Action(i[1]) }
+
+// Replay 한 판을 다시 재생하는 데 필요한 시드와 입력 기록입니다.
+type Replay struct {
+	Version int     `json:"version"`
+	Seed    uint32  `json:"seed"`
+	Steps   int     `json:"steps"` // 게임 오버 시점의 스텝 수
+	Inputs  []Input `json:"inputs"`
+}
+
+// Result 리플레이로 다시 계산한 게임 결과입니다.
+type Result struct {
+	Score           int
+	Lines           int
+	Level           int
+	DurationMs      int
+	PiecesPlaced    int
+	MaxCombo        int
+	GarbageSurvived int
+	Steps           int
+}
+
+// Validate 함수는 리플레이 형식을 검사합니다. 입력은 스텝 순서대로 정렬되어 있어야 합니다.
+func (r *Replay) Validate() error {
+	if r.Version != ReplayVersion {
+		return fmt.Errorf("%w: 지원하지 않는 버전 %d", ErrInvalidReplay, r.Version)
+	}
+	if r.Steps < 0 || r.Steps > MaxReplaySteps {
+		return fmt.Errorf("%w: 스텝 수 %d가 범위를 벗어났습니다", ErrInvalidReplay, r.Steps)
+	}
+	if len(r.Inputs) > MaxReplayInputs {
+		return fmt.Errorf("%w: 입력이 너무 많습니다", ErrInvalidReplay)
+	}
+
+	last := 0
+	for i, input := range r.Inputs {
+		if input.Step() < last || input.Step() > r.Steps {
+			return fmt.Errorf("%w: %d번째 입력의 스텝 %d가 잘못되었습니다", ErrInvalidReplay, i, input.Step())
+		}
+		if input.Action() < ActionLeft || input.Action() > ActionHardDrop {
+			return fmt.Errorf("%w: %d번째 입력의 종류 %d를 알 수 없습니다", ErrInvalidReplay, i, input[1])
+		}
+		last = input.Step()
+	}
+	return nil
+}
+
+// Simulate 함수는 리플레이를 처음부터 재생해 결과를 계산합니다.
+// 게임이 기록된 스텝 수에서 정확히 끝나지 않거나 게임 오버 뒤에 입력이 남아 있으면 ErrInvalidReplay를 반환합니다.
+func Simulate(r *Replay) (*Result, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	g := NewGame(r.Seed)
+	next := 0
+	for {
+		// 현재 스텝에 기록된 입력을 순서대로 적용
+		for next < len(r.Inputs) && r.Inputs[next].Step() == g.Steps() && !g.GameOver() {
+			g.Apply(r.Inputs[next].Action())
+			next++
+		}
+		if g.GameOver() || g.Steps() >= r.Steps {
+			break
+		}
+		g.Step()
+	}
+
+	if !g.GameOver() {
+		return nil, fmt.Errorf("%w: %d스텝에서 게임이 끝나지 않았습니다", ErrInvalidReplay, r.Steps)
+	}
+	if g.Steps() != r.Steps {
+		return nil, fmt.Errorf("%w: 게임이 %d스텝에서 끝났지만 %d스텝으로 기록되었습니다", ErrInvalidReplay, g.Steps(), r.Steps)
+	}
+	if next != len(r.Inputs) {
+		return nil, fmt.Errorf("%w: 게임 오버 뒤에 입력 %d개가 남아 있습니다", ErrInvalidReplay, len(r.Inputs)-next)
+	}
+
+	result := g.Result()
+	return &result, nil
+}
+
+// Verify 함수는 리플레이를 재생해 제출된 점수, 줄 수, 레벨과 비교합니다.
+// 일치하면 서버가 계산한 결과를 반환하고, 다르면 ErrReplayMismatch를 반환합니다.
+func Verify(r *Replay, score, lines, level int) (*Result, error) {
+	result, err := Simulate(r)
+	if err != nil {
+		return nil, err
+	}
+	if result.Score != score || result.Lines != lines || result.Level != level {
+		return nil, fmt.Errorf("%w: 제출 (점수 %d, 줄 %d, 레벨 %d), 리플레이 (점수 %d, 줄 %d, 레벨 %d)",
+			ErrReplayMismatch, score, lines, level, result.Score, result.Lines, result.Level)
+	}
+	return result, nil
+}
@@ -0,0 +1,26 @@
+package tetris
+
+// rng tetris.js의 createRng와 같은 mulberry32 난수 생성기입니다.
+// 같은 시드에서 브라우저와 서버가 같은 7-bag 순서와 가비지 빈칸 위치를 만들어야 하므로 알고리즘을 바꾸면 안 됩니다.
+type rng struct {
+	state uint32
+}
+
+// newRNG 함수는 시드로 난수 생성기를 만듭니다.
+func newRNG(seed uint32) *rng {
+	return &rng{state: seed}
+}
+
+// next 함수는 [0, 1) 범위의 난수를 반환합니다. (JS의 Math.random 대체)
+func (r *rng) next() float64 {
+	r.state += 0x6D2B79F5
+	a := r.state
+	t := (a ^ a>>15) * (1 | a)
+	t = (t + (t^t>>7)*(61|t)) ^ t
+	return float64(t^t>>14) / 4294967296
+}
+
+// intn 함수는 [0, n) 범위의 정수 난수를 반환합니다. (JS의 Math.floor(random() * n))
+func (r *rng) intn(n int) int {
+	return int(r.next() * float64(n))
+}
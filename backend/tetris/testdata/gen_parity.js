@@ -0,0 +1,218 @@
+// parity.json 생성기: frontend/games/tetris/tetris.js를 그대로 불러와 엔진 테스트의 기대값을 만듭니다.
+// tetris.js의 게임 규칙을 바꾸면 다시 실행해 parity.json을 갱신합니다.
+//
+//	node testdata/gen_parity.js > testdata/parity.json
+//
+// 게임 기록은 간단한 봇이 시드마다 입력을 만들어 tetris.js의 스텝 함수로 진행한 결과입니다.
+'use strict';
+
+const fs = require('fs');
+const path = require('path');
+const vm = require('vm');
+
+const source = fs.readFileSync(path.join(__dirname, '../../../frontend/games/tetris/tetris.js'), 'utf8');
+
+// 브라우저 API 대역: 어떤 속성을 읽거나 호출해도 같은 대역을 돌려줌
+function stub() {
+    const target = function () {};
+    return new Proxy(target, {
+        get(_, prop) {
+            if (prop === Symbol.toPrimitive) return () => '';
+            if (prop === 'then') return undefined;
+            return stub();
+        },
+        set() { return true; },
+        apply() { return stub(); },
+        construct() { return stub(); },
+    });
+}
+
+const context = vm.createContext({ document: stub(), window: stub(), localStorage: stub(), console: { log() {}, error() {} } });
+vm.runInContext(source, context);
+
+// 아래 함수는 tetris.js와 같은 전역 범위에서 실행됨
+function harness() {
+    // 화면 출력은 게임 상태에 영향이 없으므로 끔
+    drawBoard = drawNextPiece = updateScore = showComboMessage = showLevelUpMessage = () => {};
+    showGameOver = () => { gameOver = true; isPlaying = false; };
+
+    function apply(action) {
+        switch (action) {
+            case ACTIONS.LEFT: movePiece(-1); break;
+            case ACTIONS.RIGHT: movePiece(1); break;
+            case ACTIONS.SOFT_DROP: dropPiece(); break;
+            case ACTIONS.ROTATE: rotatePiece(); break;
+            case ACTIONS.HARD_DROP: hardDrop(); break;
+        }
+    }
+
+    function rngValues(seed, count) {
+        const r = createRng(seed);
+        return Array.from({ length: count }, () => r());
+    }
+
+    function bags(seed, count) {
+        rng = createRng(seed);
+        return Array.from({ length: count }, () => generateBag());
+    }
+
+    // 가득 찬 줄 cleared개를 놓고 clearLines 한 번의 점수와 콤보를 계산
+    function scoring(c) {
+        createBoard();
+        for (let row = ROWS - c.cleared; row < ROWS; row++) board[row].fill(1);
+        board[ROWS - c.cleared - 1][0] = 1;
+        level = c.level;
+        combo = c.combo;
+        lastClearWasCombo = c.lastClearWasCombo;
+        score = lines = maxCombo = 0;
+        clearLines();
+        return { ...c, score, lines, comboAfter: combo };
+    }
+
+    // 놓을 위치 평가 (높이, 구멍, 울퉁불퉁함이 작고 줄을 많이 지울수록 좋음)
+    function evaluate(ghost) {
+        const b = board.map(row => row.slice());
+        for (let row = 0; row < ghost.shape.length; row++) {
+            for (let col = 0; col < ghost.shape[row].length; col++) {
+                if (ghost.shape[row][col] === 0) continue;
+                if (ghost.y + row < 0) return -Infinity;
+                b[ghost.y + row][ghost.x + col] = 1;
+            }
+        }
+        const kept = b.filter(row => row.some(cell => cell === 0));
+        const cleared = ROWS - kept.length;
+        while (kept.length < ROWS) kept.unshift(Array(COLS).fill(0));
+
+        const heights = [];
+        let holes = 0;
+        for (let col = 0; col < COLS; col++) {
+            let top = ROWS;
+            for (let row = 0; row < ROWS; row++) {
+                if (kept[row][col] !== 0 && top === ROWS) top = row;
+                if (kept[row][col] === 0 && top < row) holes++;
+            }
+            heights.push(ROWS - top);
+        }
+        let bump = 0;
+        for (let col = 1; col < COLS; col++) bump += Math.abs(heights[col] - heights[col - 1]);
+        const total = heights.reduce((a, h) => a + h, 0);
+        return -0.51 * total + 0.76 * cleared - 0.36 * holes - 0.18 * bump;
+    }
+
+    // 회전 수와 좌우 이동으로 가장 좋은 위치를 찾아 입력 목록을 반환
+    function plan(botRng, noise) {
+        const saved = { piece: { ...piece }, isLocked, lockDelayStart, lockMoves };
+        const restore = () => {
+            piece = { ...saved.piece };
+            ({ isLocked, lockDelayStart, lockMoves } = saved);
+        };
+
+        let best = null;
+        for (let r = 0; r < 4; r++) {
+            for (let dx = -6; dx <= 6; dx++) {
+                restore();
+                const actions = [];
+                let ok = true;
+                for (let i = 0; i < r && ok; i++) {
+                    ok = rotatePiece();
+                    actions.push(ACTIONS.ROTATE);
+                }
+                for (let i = 0; i < Math.abs(dx) && ok; i++) {
+                    ok = movePiece(Math.sign(dx));
+                    actions.push(dx < 0 ? ACTIONS.LEFT : ACTIONS.RIGHT);
+                }
+                if (!ok) continue;
+                const value = evaluate(getGhostPosition()) + noise * botRng();
+                if (!best || value > best.value) best = { value, actions };
+            }
+        }
+        restore();
+        return best ? best.actions : [];
+    }
+
+    // 봇으로 한 판 진행: maxSteps까지 또는 게임 오버까지
+    function play(seed, bot) {
+        const botRng = createRng(bot.seed);
+        resetGame(seed);
+        const inputs = [];
+        const levels = [];
+        const garbage = [];
+        const record = action => {
+            if (gameOver) return;
+            inputs.push([stepCount, action]);
+            apply(action);
+        };
+
+        let planned = -1;
+        let wait = 0;
+        for (;;) {
+            if (!gameOver && piecesPlaced !== planned) {
+                if (wait > 0) {
+                    wait--;
+                } else {
+                    planned = piecesPlaced;
+                    plan(botRng, bot.noise).forEach(record);
+                    if (botRng() < bot.hardDrop) {
+                        record(ACTIONS.HARD_DROP);
+                    } else {
+                        // 몇 칸만 내리고 나머지는 중력과 잠금 딜레이로 고정
+                        for (let i = Math.floor(botRng() * 4); i > 0; i--) record(ACTIONS.SOFT_DROP);
+                    }
+                    wait = Math.floor(botRng() * bot.maxWait);
+                }
+            }
+            if (gameOver || stepCount >= bot.maxSteps) break;
+
+            const beforeLevel = level;
+            const beforeGarbage = garbageSurvived;
+            stepCount++;
+            stepGame(simNow());
+            if (level !== beforeLevel) levels.push([stepCount, level]);
+            if (garbageSurvived !== beforeGarbage) garbage.push([stepCount, garbageSurvived - beforeGarbage]);
+        }
+
+        return {
+            seed,
+            bot,
+            steps: stepCount,
+            gameOver,
+            inputs,
+            result: {
+                score, lines, level,
+                durationMs: simNow(),
+                piecesPlaced, maxCombo, garbageSurvived,
+                steps: stepCount,
+            },
+            levels,
+            garbage,
+            board: board.map(row => row.slice()),
+        };
+    }
+
+    const scoringCases = [];
+    for (const level of [1, 2, 5, 13]) {
+        for (const cleared of [1, 2, 3, 4]) {
+            scoringCases.push({ level, cleared, combo: 0, lastClearWasCombo: false });
+            scoringCases.push({ level, cleared, combo: 1, lastClearWasCombo: true });
+            scoringCases.push({ level, cleared, combo: 4, lastClearWasCombo: true });
+        }
+    }
+
+    return {
+        replayVersion: REPLAY_VERSION,
+        rng: [0, 1, 12345, 0xDEADBEEF, 0xFFFFFFFF].map(seed => ({ seed, values: rngValues(seed, 10) })),
+        bags: [0, 42, 2024, 0xCAFEBABE].map(seed => ({ seed, bags: bags(seed, 4) })),
+        scoring: scoringCases.map(scoring),
+        games: [
+            play(7, { seed: 1, noise: 0, hardDrop: 1, maxWait: 1, maxSteps: 6000 }),
+            play(12345, { seed: 2, noise: 0.3, hardDrop: 0.6, maxWait: 20, maxSteps: 30000 }),
+            play(0xDEADBEEF, { seed: 3, noise: 0.1, hardDrop: 0.8, maxWait: 5, maxSteps: 36000 }),
+            play(99, { seed: 4, noise: 20, hardDrop: 0.9, maxWait: 10, maxSteps: 36000 }),
+            play(2718281828, { seed: 5, noise: 2, hardDrop: 0.5, maxWait: 40, maxSteps: 36000 }),
+            play(4, { seed: 6, noise: 0, hardDrop: 1, maxWait: 30, maxSteps: 40000 }),
+        ],
+    };
+}
+
+const data = vm.runInContext(`(${harness})()`, context);
+process.stdout.write(JSON.stringify(data) + '\n');
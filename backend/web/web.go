@@ -0,0 +1,92 @@
+// web 패키지는 프론트엔드 정적 파일을 바이너리에 포함하고 HTTP 파일 시스템으로 제공합니다.
+package web
+
+//go:generate sh -c "rm -rf dist && mkdir -p dist && cp -R ../../frontend/. dist/ && find dist -name .DS_Store -delete && touch dist/.gitkeep"
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+)
+
+// fallbackDir 바이너리에 프론트엔드가 포함되지 않았을 때 사용하는 디렉토리입니다. (backend 디렉토리에서 go run으로 실행한 경우)
+const fallbackDir = "../frontend"
+
+// dist `go generate ./web`으로 frontend 디렉토리를 복사해 둔 뒤 빌드 시 포함되는 정적 파일입니다.
+//
+//go:embed all:dist
+var dist embed.FS
+
+// FS 함수는 프론트엔드 정적 파일 시스템을 반환합니다.
+// dir이 지정되면 디스크의 해당 디렉토리를 그대로 사용하고 (개발용), 아니면 바이너리에 포함된 파일을 사용합니다.
+// 바이너리에 프론트엔드가 포함되지 않았는데 dir도 없으면 경고를 남기고 fallbackDir을 사용하며, 그 디렉토리도 없으면 오류를 반환합니다.
+func FS(dir string) (fs.FS, error) {
+	if dir != "" {
+		if _, err := os.Stat(dir); err != nil {
+			return nil, fmt.Errorf("정적 파일 디렉토리를 찾을 수 없습니다: %v", err)
+		}
+		log.Printf("정적 파일을 디스크에서 제공합니다: %s", dir)
+		return os.DirFS(dir), nil
+	}
+
+	embedded, err := fs.Sub(dist, "dist")
+	if err != nil {
+		return nil, err
+	}
+
+	// go generate 없이 빌드한 경우 포함된 파일이 비어 있습니다.
+	if _, err := fs.Stat(embedded, "index.html"); err == nil {
+		return embedded, nil
+	}
+	if _, err := os.Stat(fallbackDir); err != nil {
+		return nil, fmt.Errorf("바이너리에 프론트엔드가 포함되지 않았고 %s도 없습니다. `go generate ./web` 후 다시 빌드하거나 -static-dir(STATIC_DIR)로 프론트엔드 디렉토리를 지정하세요", fallbackDir)
+	}
+	log.Printf("경고: 바이너리에 프론트엔드가 포함되지 않아 %s를 디스크에서 제공합니다 (배포용 빌드는 `go generate ./web` 후 빌드)", fallbackDir)
+	return os.DirFS(fallbackDir), nil
+}
+
+// HTTPFS 함수는 fsys의 하위 디렉토리 dir을 디렉토리 목록 노출 없이 제공하는 http.FileSystem을 반환합니다.
+func HTTPFS(fsys fs.FS, dir string) http.FileSystem {
+	sub, err := fs.Sub(fsys, dir)
+	if err != nil {
+		// fs.Sub는 잘못된 경로에서만 실패하므로 빈 파일 시스템으로 대체합니다.
+		log.Printf("정적 파일 경로 %s 설정 실패: %v", dir, err)
+		return onlyFilesFS{http.FS(emptyFS{})}
+	}
+	return onlyFilesFS{http.FS(sub)}
+}
+
+// onlyFilesFS 디렉토리 목록 조회를 막는 http.FileSystem 래퍼입니다.
+type onlyFilesFS struct {
+	fs http.FileSystem
+}
+
+// Open 함수는 파일을 열되 디렉토리 목록은 비워서 반환합니다.
+func (o onlyFilesFS) Open(name string) (http.File, error) {
+	f, err := o.fs.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return noReaddirFile{f}, nil
+}
+
+// noReaddirFile Readdir 결과를 항상 비워 두는 http.File입니다.
+type noReaddirFile struct {
+	http.File
+}
+
+// Readdir 함수는 디렉토리 목록을 노출하지 않습니다.
+func (noReaddirFile) Readdir(int) ([]fs.FileInfo, error) {
+	return nil, nil
+}
+
+// emptyFS 아무 파일도 없는 파일 시스템입니다.
+type emptyFS struct{}
+
+// Open 함수는 항상 파일이 없다는 오류를 반환합니다.
+func (emptyFS) Open(name string) (fs.File, error) {
+	return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+}
@@ -0,0 +1,43 @@
+// wsconn 패키지는 대전과 관전 WebSocket 연결이 함께 쓰는 연결 유지(ping/pong) 설정과 쓰기 함수를 정의합니다.
+package wsconn
+
+import (
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// WebSocket 연결 설정입니다.
+const (
+	WriteWait  = 10 * time.Second  // 메시지 하나를 쓰는 최대 시간
+	PongWait   = 60 * time.Second  // 상대 응답(pong)을 기다리는 최대 시간
+	PingPeriod = PongWait * 9 / 10 // ping 전송 주기 (PongWait보다 짧아야 함)
+)
+
+// KeepAlive 함수는 읽기 크기 제한을 설정하고, pong을 받을 때마다 읽기 제한 시간을 연장하도록 설정합니다.
+// 읽기 고루틴을 시작하기 전에 호출합니다.
+func KeepAlive(conn *websocket.Conn, maxMessageSize int64) {
+	conn.SetReadLimit(maxMessageSize)
+	conn.SetReadDeadline(time.Now().Add(PongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(PongWait))
+	})
+}
+
+// WriteJSON 함수는 쓰기 제한 시간을 두고 메시지 하나를 보냅니다.
+func WriteJSON(conn *websocket.Conn, v any) error {
+	conn.SetWriteDeadline(time.Now().Add(WriteWait))
+	return conn.WriteJSON(v)
+}
+
+// WritePing 함수는 연결 확인용 ping을 보냅니다.
+func WritePing(conn *websocket.Conn) error {
+	conn.SetWriteDeadline(time.Now().Add(WriteWait))
+	return conn.WriteMessage(websocket.PingMessage, nil)
+}
+
+// WriteClose 함수는 정상 종료 메시지를 보냅니다. 연결은 호출한 쪽이 닫습니다.
+func WriteClose(conn *websocket.Conn) {
+	conn.SetWriteDeadline(time.Now().Add(WriteWait))
+	conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+}
@@ -17,6 +17,33 @@ if (window.location.hostname === 'localhost' || window.location.hostname === '12
     }
 }
 
+/**
+ * 리프레시 토큰으로 새 액세스 토큰과 리프레시 토큰을 발급받는 함수
+ * @returns {Promise<boolean>} 갱신 성공 여부
+ */
+async function refreshAccessToken() {
+    const refreshToken = localStorage.getItem('refresh_token');
+    if (!refreshToken) {
+        return false;
+    }
+
+    const response = await fetch(`${API_BASE_URL}/auth/refresh`, {
+        method: 'POST',
+        headers: {
+            'Content-Type': 'application/json'
+        },
+        body: JSON.stringify({ refresh_token: refreshToken })
+    });
+    if (!response.ok) {
+        return false;
+    }
+
+    const data = await response.json();
+    localStorage.setItem('token', data.token);
+    localStorage.setItem('refresh_token', data.refresh_token);
+    return true;
+}
+
 /**
  * API 호출을 위한 범용 함수
  * @param {string} endpoint - API 엔드포인트 경로 (예: '/scores')
@@ -51,12 +78,19 @@ async function apiCall(endpoint, options = {}, requiresAuth = false) {
     
     try {
         // API 요청 실행
-        const response = await fetch(`${API_BASE_URL}${endpoint}`, requestOptions);
+        let response = await fetch(`${API_BASE_URL}${endpoint}`, requestOptions);
+
+        // 액세스 토큰이 만료되었으면 리프레시 토큰으로 다시 발급받아 한 번 더 요청
+        if (response.status === 401 && requiresAuth && await refreshAccessToken()) {
+            requestOptions.headers['Authorization'] = `Bearer ${localStorage.getItem('token')}`;
+            response = await fetch(`${API_BASE_URL}${endpoint}`, requestOptions);
+        }
         
         // 401 Unauthorized 응답 처리
         if (response.status === 401 && requiresAuth) {
             // 로컬 스토리지에서 토큰 제거
             localStorage.removeItem('token');
+            localStorage.removeItem('refresh_token');
             localStorage.removeItem('username');
             localStorage.removeItem('nickname');
             
@@ -75,4 +109,4 @@ async function apiCall(endpoint, options = {}, requiresAuth = false) {
 }
 
 // 모듈로 내보내기
-export { API_BASE_URL, apiCall }; 
\ No newline at end of file
+export { API_BASE_URL, apiCall, refreshAccessToken }; 
\ No newline at end of file
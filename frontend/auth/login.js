@@ -73,8 +73,9 @@ async function handleLogin() {
         // 로그인 성공 처리
         const data = await response.json();
         
-        // JWT 토큰 저장
+        // JWT 토큰과 리프레시 토큰 저장 (액세스 토큰이 만료되면 리프레시 토큰으로 다시 발급)
         localStorage.setItem('token', data.token);
+        localStorage.setItem('refresh_token', data.refresh_token);
         localStorage.setItem('username', username);
         
         // 닉네임이 있으면 저장
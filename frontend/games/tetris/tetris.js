@@ -81,7 +81,7 @@ let isPlaying = false;
 let score = 0;
 let level = 1;
 let lines = 0;
-let dropStart = Date.now();
+let dropStart = 0;
 let dropInterval = 500;
 let piece = null;
 let nextPiece = null;
@@ -90,8 +90,30 @@ let combo = 0;
 let lastClearWasCombo = false;
 let lastGarbageTime = 0;
 const garbageInterval = 12000;
+let piecesPlaced = 0;      // 고정한 블록 수 (플레이 기록용)
+let maxCombo = 0;          // 최대 콤보 (플레이 기록용)
+let garbageSurvived = 0;   // 추가된 가비지 라인 수 (플레이 기록용)
 const LEVEL_UP_INTERVAL = 30000;   // 레벨업 간격: 30초 (밀리초)
 
+// 리플레이 관련 상수와 변수
+// 게임 로직은 STEP_MS 단위 고정 스텝으로 진행되고, 서버(backend/tetris)는 시드와 입력 기록으로 같은 게임을 다시 재생해 점수를 검증합니다.
+// 게임 규칙을 바꾸면 서버 엔진도 같이 바꾸고 REPLAY_VERSION을 올려야 합니다.
+const STEP_MS = 10;               // 시뮬레이션 한 스텝의 게임 시간 (밀리초)
+const REPLAY_VERSION = 1;
+const ACTIONS = {                 // 리플레이 입력 종류 (서버의 tetris.Action과 같은 값)
+    LEFT: 0,
+    RIGHT: 1,
+    SOFT_DROP: 2,
+    ROTATE: 3,
+    HARD_DROP: 4
+};
+let stepCount = 0;                // 지금까지 진행한 스텝 수
+let replaySeed = 0;               // 이번 게임의 난수 시드
+let replayInputs = [];            // [스텝, 입력 종류] 목록
+let gameSession = null;           // 서버가 발급한 게임 세션 (로그인하지 않았으면 null)
+let isStarting = false;           // 게임 세션을 발급받는 중인지 여부
+let watchingReplay = null;        // 재생 중인 리플레이 ({ nickname, inputs, next }, 직접 플레이 중이면 null)
+
 // Lock delay 관련 변수
 let isLocked = false;         // 블록이 잠금 상태인지 여부
 let lockDelayStart = 0;       // 잠금 딜레이 시작 시간
@@ -109,6 +131,37 @@ let pieceBag = [];
 let pauseStartTime = 0;     // 일시정지 시작 시간
 let totalPausedTime = 0;    // 총 일시정지 시간
 
+// 시드 기반 난수 생성기 (mulberry32, 서버의 tetris/rng.go와 같은 알고리즘)
+function createRng(seed) {
+    let a = seed >>> 0;
+    return function() {
+        a = (a + 0x6D2B79F5) | 0;
+        let t = Math.imul(a ^ (a >>> 15), 1 | a);
+        t = (t + Math.imul(t ^ (t >>> 7), 61 | t)) ^ t;
+        return ((t ^ (t >>> 14)) >>> 0) / 4294967296;
+    };
+}
+
+// 새 게임 시드 생성
+function randomSeed() {
+    const values = new Uint32Array(1);
+    window.crypto.getRandomValues(values);
+    return values[0];
+}
+
+// 7-bag과 가비지 빈칸 위치에 사용하는 난수 생성기
+let rng = createRng(randomSeed());
+
+// 현재 게임 시간 (일시정지를 제외한 스텝 기준 시간, 밀리초)
+function simNow() {
+    return stepCount * STEP_MS;
+}
+
+// 리플레이 입력 기록
+function recordInput(action) {
+    replayInputs.push([stepCount, action]);
+}
+
 // 새로운 7-bag을 생성하고 섞는 함수
 function generateBag() {
     // 1부터 7까지의 숫자 배열 생성 (7개 테트로미노 타입)
@@ -116,7 +169,7 @@ function generateBag() {
     
     // Fisher-Yates 알고리즘으로 배열 섞기
     for (let i = newBag.length - 1; i > 0; i--) {
-        const j = Math.floor(Math.random() * (i + 1));
+        const j = Math.floor(rng() * (i + 1));
         [newBag[i], newBag[j]] = [newBag[j], newBag[i]];
     }
     
@@ -165,6 +218,64 @@ if (window.location.hostname === 'localhost' || window.location.hostname === '12
     }
 }
 
+// 액세스 토큰이 만료되면 리프레시 토큰으로 다시 발급 (여러 요청이 동시에 만료되어도 한 번만 갱신)
+// 리프레시 토큰은 한 번만 쓸 수 있으므로 같은 토큰으로 두 번 갱신하면 서버가 로그인 세션을 폐기합니다.
+let refreshPromise = null;
+function refreshAccessToken() {
+    const refreshToken = localStorage.getItem('refresh_token');
+    if (!refreshToken) {
+        return Promise.resolve(false);
+    }
+
+    if (!refreshPromise) {
+        refreshPromise = fetch(`${API_URL}/auth/refresh`, {
+            method: 'POST',
+            headers: {
+                'Content-Type': 'application/json'
+            },
+            body: JSON.stringify({ refresh_token: refreshToken })
+        })
+            .then(async response => {
+                if (!response.ok) {
+                    // 리프레시 토큰도 만료되었거나 폐기되었으면 다시 로그인해야 함
+                    localStorage.removeItem('token');
+                    localStorage.removeItem('refresh_token');
+                    return false;
+                }
+
+                const data = await response.json();
+                localStorage.setItem('token', data.token);
+                localStorage.setItem('refresh_token', data.refresh_token);
+                return true;
+            })
+            .catch(error => {
+                console.error('토큰 갱신 오류:', error);
+                return false;
+            })
+            .finally(() => {
+                refreshPromise = null;
+            });
+    }
+    return refreshPromise;
+}
+
+// 인증이 필요한 요청 - 토큰이 만료되어 401이면 토큰을 갱신한 뒤 한 번 더 요청
+async function authFetch(url, options = {}) {
+    const send = () => fetch(url, {
+        ...options,
+        headers: {
+            ...options.headers,
+            'Authorization': `Bearer ${localStorage.getItem('token')}`
+        }
+    });
+
+    let response = await send();
+    if (response.status === 401 && await refreshAccessToken()) {
+        response = await send();
+    }
+    return response;
+}
+
 // 게임 시작 시간 저장 변수
 let gameStartTime = 0;
 
@@ -204,12 +315,62 @@ function showStartMessage() {
     ctx.fillText('아무 키나 눌러 시작하세요', canvas.width / 2, canvas.height / 2);
 }
 
+// 서버에서 게임 세션(세션 ID와 시드) 발급 - 점수는 발급받은 세션으로만 저장할 수 있음
+async function startGameSession() {
+    const token = localStorage.getItem('token');
+    if (!token) {
+        return null;
+    }
+
+    try {
+        const response = await authFetch(`${API_URL}/tetris/sessions`, {
+            method: 'POST'
+        });
+
+        if (!response.ok) {
+            throw new Error('게임 세션 발급에 실패했습니다.');
+        }
+
+        return await response.json();
+    } catch (error) {
+        console.error('게임 세션 오류:', error);
+        return null;
+    }
+}
+
 // 게임 시작
-function startGame() {
+async function startGame() {
+    if (isStarting) {
+        return;
+    }
     if (animationId) {
         cancelAnimationFrame(animationId);
     }
+
+    // 세션을 발급받는 동안 이전 게임 입력 중단
+    isStarting = true;
+    isPlaying = false;
+    gameSession = await startGameSession();
+    isStarting = false;
+    
+    // 세션 시드(로그인하지 않았으면 임의 시드)로 게임 초기화
+    watchingReplay = null;
+    resetGame(gameSession ? gameSession.seed : randomSeed());
+    startButton.textContent = '게임 재시작';
+    
+    console.log('게임 시작!');
     
+    // 게임 루프 시작
+    animationId = requestAnimationFrame(gameLoop);
+}
+
+// 시드로 난수 생성기, 리플레이 기록, 게임 상태를 초기화
+function resetGame(seed) {
+    replaySeed = seed;
+    rng = createRng(replaySeed);
+    replayInputs = [];
+    stepCount = 0;
+
     createBoard();
     pieceBag = generateBag();
     piece = getNextPiece();
@@ -221,21 +382,82 @@ function startGame() {
     level = 1;
     lines = 0;
     dropInterval = 500; // 초기 속도 설정
-    dropStart = Date.now();
+    dropStart = 0;
     gameStartTime = Date.now(); // 게임 시작 시간 저장
     combo = 0;
     lastClearWasCombo = false;
-    lastGarbageTime = Date.now();
+    lastGarbageTime = 0;
+    isLocked = false;
+    lockDelayStart = 0;
+    lockMoves = 0;
     totalPausedTime = 0; // 총 일시정지 시간 초기화
+    piecesPlaced = 0;
+    maxCombo = 0;
+    garbageSurvived = 0;
     
     updateScore();
-    startButton.textContent = '게임 재시작';
     pauseButton.textContent = '일시정지 (ESC)';
-    
-    console.log('게임 시작!');
-    
-    // 게임 루프 시작
-    animationId = requestAnimationFrame(gameLoop);
+}
+
+// 저장된 리플레이 재생 - 서버에서 받은 시드와 입력 기록을 같은 스텝에 다시 적용
+async function watchReplay(playId) {
+    if (isStarting) {
+        return;
+    }
+
+    try {
+        const response = await fetch(`${API_URL}/tetris/replays/${playId}`);
+        if (!response.ok) {
+            throw new Error('리플레이를 가져오는데 실패했습니다.');
+        }
+
+        const data = await response.json();
+        if (data.replay.version !== REPLAY_VERSION) {
+            throw new Error('지원하지 않는 리플레이 버전입니다.');
+        }
+
+        if (animationId) {
+            cancelAnimationFrame(animationId);
+        }
+        gameSession = null;
+        watchingReplay = {
+            nickname: data.play.nickname,
+            inputs: data.replay.inputs,
+            next: 0
+        };
+        resetGame(data.replay.seed);
+        startButton.textContent = '게임 시작';
+
+        console.log(`${data.play.nickname}님의 리플레이 재생 (${data.play.score}점)`);
+        animationId = requestAnimationFrame(gameLoop);
+    } catch (error) {
+        console.error('리플레이 오류:', error);
+    }
+}
+
+// 현재 스텝에 기록된 리플레이 입력 적용 (서버의 tetris.Simulate와 같은 순서)
+function applyReplayInputs() {
+    const inputs = watchingReplay.inputs;
+    while (watchingReplay.next < inputs.length && inputs[watchingReplay.next][0] === stepCount && !gameOver) {
+        switch (inputs[watchingReplay.next][1]) {
+            case ACTIONS.LEFT:
+                movePiece(-1);
+                break;
+            case ACTIONS.RIGHT:
+                movePiece(1);
+                break;
+            case ACTIONS.SOFT_DROP:
+                dropPiece();
+                break;
+            case ACTIONS.ROTATE:
+                rotatePiece();
+                break;
+            case ACTIONS.HARD_DROP:
+                hardDrop();
+                break;
+        }
+        watchingReplay.next++;
+    }
 }
 
 // 게임 일시정지 토글
@@ -253,8 +475,7 @@ function togglePause() {
         pauseButton.textContent = '일시정지 (ESC)';
         console.log('게임 재개');
         
-        // 게임 루프 재시작
-        dropStart = Date.now();
+        // 게임 루프 재시작 (일시정지 동안 게임 시간은 흐르지 않음)
         animationId = requestAnimationFrame(gameLoop);
     }
 }
@@ -427,12 +648,12 @@ function movePiece(dir) {
         if (isLocked) {
             lockMoves++;
             if (lockMoves < MAX_LOCK_MOVES) {
-                lockDelayStart = Date.now();
+                lockDelayStart = simNow();
             }
         } else {
             // 이제 막 충돌 상태가 되었다면 lock delay 시작
             isLocked = true;
-            lockDelayStart = Date.now();
+            lockDelayStart = simNow();
             lockMoves = 0;
         }
     } else {
@@ -508,12 +729,12 @@ function rotatePiece() {
         if (isLocked) {
             lockMoves++;
             if (lockMoves < MAX_LOCK_MOVES) {
-                lockDelayStart = Date.now();
+                lockDelayStart = simNow();
             }
         } else {
             // 이제 막 충돌 상태가 되었다면 lock delay 시작
             isLocked = true;
-            lockDelayStart = Date.now();
+            lockDelayStart = simNow();
             lockMoves = 0;
         }
     } else {
@@ -534,7 +755,7 @@ function dropPiece() {
         // 블록이 아직 잠금 상태가 아니면 lock delay 시작
         if (!isLocked) {
             isLocked = true;
-            lockDelayStart = Date.now();
+            lockDelayStart = simNow();
             lockMoves = 0;
         }
     } else {
@@ -542,7 +763,7 @@ function dropPiece() {
         isLocked = false;
     }
     
-    dropStart = Date.now();
+    dropStart = simNow();
 }
 
 // 블록 바로 내리기
@@ -576,6 +797,7 @@ function lockPiece() {
         }
     }
     
+    piecesPlaced++;
     clearLines();
     
     // 새 블록 생성 및 충돌 확인
@@ -646,6 +868,7 @@ function clearLines() {
             combo = 1;
             lastClearWasCombo = true;
         }
+        maxCombo = Math.max(maxCombo, combo);
         
         const linePoints = [40, 100, 300, 1200]; // 1, 2, 3, 4줄
         // 레벨에 따라 점수 증가
@@ -712,6 +935,13 @@ function showGameOver() {
     ctx.fillText(`최종 점수: ${score}`, canvas.width / 2, canvas.height / 2 + 10);
     ctx.fillText('아무 키나 눌러 다시 시작', canvas.width / 2, canvas.height / 2 + 50);
     
+    // 리플레이 재생이 끝난 경우 점수를 저장하지 않음
+    if (watchingReplay) {
+        ctx.fillText(`${watchingReplay.nickname}님의 리플레이`, canvas.width / 2, canvas.height / 2 + 90);
+        watchingReplay = null;
+        return;
+    }
+    
     // 점수 저장 - alert 제거
     saveScore(score, lines, level).then(result => {
         // alert 호출 제거하고 조용히 랭킹 업데이트만 수행
@@ -729,37 +959,53 @@ function showGameOver() {
     console.log("게임 오버 화면 표시됨");
 }
 
-// 게임 루프
+// 게임 루프 - 실제 경과 시간만큼 고정 스텝을 진행하고 화면을 그림
 function gameLoop() {
     // 게임 오버 또는 일시정지면 루프 중단
     if (gameOver || isPaused) {
         return;
     }
     
-    const now = Date.now();
+    // 실제 게임 진행 시간 = 현재 시간 - 게임 시작 시간 - 총 일시정지 시간
+    const gameTime = Date.now() - gameStartTime - totalPausedTime;
+    
+    while (!gameOver && (stepCount + 1) * STEP_MS <= gameTime) {
+        if (watchingReplay) {
+            applyReplayInputs();
+            if (gameOver) {
+                break;
+            }
+        }
+        stepCount++;
+        stepGame(simNow());
+    }
+    
+    // 현재 게임 상태가 여전히 유효한지 확인
+    if (!gameOver) {
+        drawBoard();
+        animationId = requestAnimationFrame(gameLoop);
+    }
+}
+
+// 게임 한 스텝 진행 (서버의 tetris.Game.Step과 같은 순서: 레벨업, 잠금 딜레이, 중력, 가비지)
+function stepGame(now) {
     const delta = now - dropStart;
     
-    // 시간 기반 레벨업 시스템 - 일시정지 시간을 고려하여 실제 게임 시간 계산
-    if (isPlaying) {
-        // 실제 게임 진행 시간 = 현재 시간 - 게임 시작 시간 - 총 일시정지 시간
-        const gameTime = now - gameStartTime - totalPausedTime;
+    // 시간 기반 레벨업 시스템 (30초당 1레벨씩 상승)
+    const newLevel = Math.floor(now / LEVEL_UP_INTERVAL) + 1;
+    
+    // 레벨이 변경된 경우 처리
+    if (newLevel > level) {
+        level = newLevel;
+        // 레벨에 따른 dropInterval 계산
+        dropInterval = Math.max(50, 500 - ((level - 1) * 40));
+        console.log(`레벨 업! 레벨 ${level}, 드롭 간격: ${dropInterval}ms`);
         
-        // 시간에 따라 레벨 계산 (30초당 1레벨씩 상승)
-        const newLevel = Math.floor(gameTime / LEVEL_UP_INTERVAL) + 1;
+        // 레벨업 시각적 효과
+        showLevelUpMessage(level);
         
-        // 레벨이 변경된 경우 처리
-        if (newLevel > level) {
-            level = newLevel;
-            // 레벨에 따른 dropInterval 계산
-            dropInterval = Math.max(50, 500 - ((level - 1) * 40));
-            console.log(`레벨 업! 레벨 ${level}, 드롭 간격: ${dropInterval}ms`);
-            
-            // 레벨업 시각적 효과
-            showLevelUpMessage(level);
-            
-            // 점수 화면 갱신
-            updateScore();
-        }
+        // 점수 화면 갱신
+        updateScore();
     }
     
     // lock delay 처리
@@ -776,6 +1022,10 @@ function gameLoop() {
         dropPiece();
     }
     
+    if (gameOver) {
+        return;
+    }
+    
     // 레벨 2 이상에서 가비지 라인 추가
     if (level >= 2) {
         const garbageDelta = now - lastGarbageTime;
@@ -791,12 +1041,6 @@ function gameLoop() {
             lastGarbageTime = now;
         }
     }
-    
-    // 현재 게임 상태가 여전히 유효한지 확인
-    if (!gameOver) {
-        drawBoard();
-        animationId = requestAnimationFrame(gameLoop);
-    }
 }
 
 // 키보드 입력 처리
@@ -826,6 +1070,11 @@ function handleKeyPress(e) {
         return;
     }
 
+    // 리플레이 재생 중에는 조작 입력 무시
+    if (watchingReplay) {
+        return;
+    }
+
     // 게임 일시정지 상태에서는 다른 키 입력 무시
     if (isPaused) {
         return;
@@ -840,18 +1089,23 @@ function handleKeyPress(e) {
     // 일반 게임 플레이 시 키 입력 처리
     switch(e.keyCode) {
         case 37: // 왼쪽
+            recordInput(ACTIONS.LEFT);
             movePiece(-1);
             break;
         case 39: // 오른쪽
+            recordInput(ACTIONS.RIGHT);
             movePiece(1);
             break;
         case 40: // 아래
+            recordInput(ACTIONS.SOFT_DROP);
             dropPiece();
             break;
         case 38: // 위
+            recordInput(ACTIONS.ROTATE);
             rotatePiece();
             break;
         case 32: // 스페이스
+            recordInput(ACTIONS.HARD_DROP);
             hardDrop();
             break;
         case 80: // P 키
@@ -860,25 +1114,42 @@ function handleKeyPress(e) {
     }
 }
 
-// 점수 저장 함수 - 최고 점수인 경우에만 서버에 저장
+// 점수 저장 함수 - 모든 게임을 플레이 기록으로 저장하고, 최고 점수면 랭킹도 갱신
 async function saveScore(score, lines, level) {
     const token = localStorage.getItem('token');
     if (!token) {
         console.log('점수 저장을 위해 로그인이 필요합니다.');
         return null;
     }
+    if (!gameSession) {
+        console.log('게임 세션이 없어 점수를 저장할 수 없습니다.');
+        return null;
+    }
     
     try {
-        const response = await fetch(`${API_URL}/tetris/score`, {
+        const response = await authFetch(`${API_URL}/tetris/score`, {
         method: 'POST',
         headers: {
-            'Content-Type': 'application/json',
-            'Authorization': `Bearer ${token}`
+            'Content-Type': 'application/json'
         },
             body: JSON.stringify({
+                session_id: gameSession.session_id,
+                session_signature: gameSession.signature,
                 score,
                 lines,
-                level
+                level,
+                duration_ms: simNow(),
+                pieces_placed: piecesPlaced,
+                max_combo: maxCombo,
+                garbage_survived: garbageSurvived,
+                save_replay: true,
+                // 서버가 같은 게임을 다시 재생해 점수를 검증하기 위한 리플레이
+                replay: {
+                    version: REPLAY_VERSION,
+                    seed: replaySeed,
+                    steps: stepCount,
+                    inputs: replayInputs
+                }
             })
         });
         
@@ -894,31 +1165,38 @@ async function saveScore(score, lines, level) {
     }
 }
 
-// 랭킹 표시 함수 업데이트
-async function updateLeaderboard() {
+// 리플레이가 있는 랭킹 행의 재생 버튼
+function replayButton(entry) {
+    if (!entry.has_replay) {
+        return '<span class="rank-replay"></span>';
+    }
+    return `<button class="rank-replay" data-play-id="${entry.play_id}" title="리플레이 보기">▶</button>`;
+}
+
+// 랭킹 표시 함수 업데이트 (pushedLeaderboard가 있으면 실시간 랭킹으로 받은 목록을 그대로 사용)
+async function updateLeaderboard(pushedLeaderboard) {
     const rankingList = document.getElementById('ranking-list');
     const myBestScoreElement = document.getElementById('my-best-score');
     
     // 랭킹 목록 가져오기
     try {
-        const response = await fetch(`${API_URL}/tetris/leaderboard?limit=10`);
-        if (!response.ok) {
-            throw new Error('랭킹 데이터를 가져오는데 실패했습니다.');
+        let leaderboard = pushedLeaderboard;
+        if (!leaderboard) {
+            const response = await fetch(`${API_URL}/tetris/leaderboard?limit=10`);
+            if (!response.ok) {
+                throw new Error('랭킹 데이터를 가져오는데 실패했습니다.');
+            }
+
+            const data = await response.json();
+            leaderboard = data.leaderboard || [];
         }
         
-        const data = await response.json();
-        const leaderboard = data.leaderboard || [];
-        
         // 내 점수 정보 가져오기
         const token = localStorage.getItem('token');
         let myScoreData = null;
         
         if (token) {
-            const myScoreResponse = await fetch(`${API_URL}/tetris/user/score`, {
-                headers: {
-                    'Authorization': `Bearer ${token}`
-                }
-            });
+            const myScoreResponse = await authFetch(`${API_URL}/tetris/user/score`);
             
             if (myScoreResponse.ok) {
                 myScoreData = await myScoreResponse.json();
@@ -942,12 +1220,47 @@ async function updateLeaderboard() {
                 
                 return `
                     <li class="ranking-item ${isMe ? 'my-rank' : ''}">
-                        <span class="rank-position">${index + 1}</span>
+                        <span class="rank-position">${entry.rank || index + 1}</span>
                         <span class="rank-name">${nickname}</span>
                         <span class="rank-score">${entry.score.toLocaleString()}</span>
+                        ${replayButton(entry)}
                     </li>
                 `;
             }).join('');
+
+            // 내 순위가 상위 목록 밖이면 내 주변 순위를 이어서 표시
+            if (token && myScoreData && myScoreData.hasRecord && myScoreData.rank > leaderboard.length) {
+                const aroundResponse = await authFetch(`${API_URL}/tetris/leaderboard/around-me?radius=2`);
+
+                if (aroundResponse.ok) {
+                    const aroundData = await aroundResponse.json();
+                    const around = (aroundData.leaderboard || []).filter(
+                        entry => !leaderboard.some(top => top.user_id === entry.user_id)
+                    );
+
+                    if (around.length > 0) {
+                        rankingList.innerHTML += `
+                            <li class="ranking-item">
+                                <span class="rank-position">⋮</span>
+                                <span class="rank-name"></span>
+                                <span class="rank-score"></span>
+                            </li>
+                        ` + around.map(entry => {
+                            const nickname = entry.nickname || entry.username;
+                            const isMe = myScoreData.nickname === entry.nickname;
+
+                            return `
+                                <li class="ranking-item ${isMe ? 'my-rank' : ''}">
+                                    <span class="rank-position">${entry.rank}</span>
+                                    <span class="rank-name">${nickname}</span>
+                                    <span class="rank-score">${entry.score.toLocaleString()}</span>
+                                    ${replayButton(entry)}
+                                </li>
+                            `;
+                        }).join('');
+                    }
+                }
+            }
         }
         
         // 내 최고 점수 표시
@@ -1010,7 +1323,7 @@ function generateGarbageLine() {
     // 랜덤 위치에 빈 공간 생성
     const emptyPositions = [];
     while (emptyPositions.length < emptySpaces) {
-        const pos = Math.floor(Math.random() * COLS);
+        const pos = Math.floor(rng() * COLS);
         if (!emptyPositions.includes(pos)) {
             emptyPositions.push(pos);
             garbageLine[pos] = 0; // 빈 공간으로 설정
@@ -1049,6 +1362,8 @@ function addGarbageLines(lines = 1) {
         }
     }
     
+    garbageSurvived += lines;
+    
     // 현재 피스 위치 조정 (가비지 라인만큼 위로)
     piece.y -= lines;
     
@@ -1146,14 +1461,38 @@ document.addEventListener('keydown', handleKeyPress);
 startButton.addEventListener('click', startGame);
 pauseButton.addEventListener('click', togglePause);
 
+// 랭킹 목록의 리플레이 재생 버튼
+document.getElementById('ranking-list').addEventListener('click', e => {
+    const button = e.target.closest('.rank-replay[data-play-id]');
+    if (button) {
+        button.blur(); // 스페이스 키 입력이 버튼을 다시 누르지 않도록
+        watchReplay(button.dataset.playId);
+    }
+});
+
 // 메뉴 버튼 이벤트 리스너
 const menuButton = document.getElementById('menu-button');
 menuButton.addEventListener('click', () => {
     window.location.href = '../../menu/games.html';
 });
 
+// 실시간 랭킹 구독 (SSE): 다른 플레이어의 새 최고 점수로 상위 10명이 바뀌면 바로 다시 표시
+// 연결이 끊기면 EventSource가 자동으로 다시 연결합니다.
+function subscribeLeaderboard() {
+    if (!window.EventSource) {
+        return;
+    }
+
+    const source = new EventSource(`${API_URL}/tetris/leaderboard/stream?limit=10`);
+    source.addEventListener('leaderboard', (event) => {
+        const data = JSON.parse(event.data);
+        updateLeaderboard(data.leaderboard || []);
+    });
+}
+
 // 페이지 로드시 게임 초기화
 document.addEventListener('DOMContentLoaded', function() {
     init();
     updateLeaderboard();
+    subscribeLeaderboard();
 });
@@ -3,6 +3,17 @@
 // 공통 API 유틸리티 가져오기 (추후 구현)
 // import { apiCall } from '../assets/js/api.js';
 
+// 백엔드 API URL 설정 (현재 호스트명에 따라 결정)
+let API_URL = '';
+if (window.location.hostname === 'localhost' || window.location.hostname === '127.0.0.1') {
+    API_URL = 'http://localhost:8080';
+} else {
+    API_URL = 'https://api.' + window.location.hostname.replace('www.', '');
+    if (window.location.hostname.includes('kakaotech.my')) {
+        API_URL = 'https://api.kakaotech.my';
+    }
+}
+
 // DOM 요소
 const usernameElement = document.getElementById('username');
 const logoutButton = document.getElementById('logout-btn');
@@ -32,10 +43,39 @@ function loadUserInfo() {
     }
 }
 
+// 로그아웃 요청 함수 (token이 없으면 리프레시 토큰만 보냄)
+function requestLogout(token, refreshToken) {
+    const headers = { 'Content-Type': 'application/json' };
+    if (token) {
+        headers['Authorization'] = `Bearer ${token}`;
+    }
+    return fetch(`${API_URL}/auth/logout`, {
+        method: 'POST',
+        headers,
+        body: JSON.stringify({ refresh_token: refreshToken || '' })
+    });
+}
+
 // 로그아웃 함수
-function handleLogout() {
+async function handleLogout() {
+    // 서버에서 현재 토큰을 폐기하고 로그인 세션 해제 (실패해도 이 기기에서는 로그아웃)
+    const token = localStorage.getItem('token');
+    const refreshToken = localStorage.getItem('refresh_token');
+    if (token || refreshToken) {
+        try {
+            const response = await requestLogout(token, refreshToken);
+            // 액세스 토큰이 만료되었으면 리프레시 토큰만으로 세션 해제
+            if (response.status === 401 && token && refreshToken) {
+                await requestLogout(null, refreshToken);
+            }
+        } catch (error) {
+            console.error('로그아웃 요청 오류:', error);
+        }
+    }
+
     // 로컬 스토리지에서 토큰 및 사용자 정보 삭제
     localStorage.removeItem('token');
+    localStorage.removeItem('refresh_token');
     localStorage.removeItem('username');
     localStorage.removeItem('nickname');
     